@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"text/tabwriter"
 	"time"
 
 	"github.com/alecthomas/kong"
@@ -31,20 +36,122 @@ type CLI struct {
 	CopyEdit CopyEditCmd `cmd:"" help:"Copy-edit a markdown file in place"`
 	Devices  DevicesCmd  `cmd:"" help:"List available audio devices"`
 	Config   ConfigCmd   `cmd:"" help:"Manage configuration"`
+	Cache    CacheCmd    `cmd:"" help:"Inspect or prune the transcription cache"`
+	Prompts  PromptsCmd  `cmd:"" help:"Manage prompt templates"`
+	Usage    UsageCmd    `cmd:"" help:"Report token usage and estimated cost"`
 }
 
 // TUICmd is the default command that runs the TUI.
 type TUICmd struct {
-	Output          string `arg:"" optional:"" help:"Output file path"`
-	Name            string `flag:"" optional:"" help:"Working name (overrides git branch detection)"`
-	MaxDuration     string `flag:"" default:"1h" help:"Max recording duration"`
-	MaxBytes        int64  `flag:"" default:"268435456" help:"Max file size (256MB)"`
-	Mode            string `flag:"" default:"memos" help:"Content mode: memos (full) or journal (minimal)"`
-	OutputDir       string `flag:"" optional:"" help:"Output dir (default: content/posts for memos, . for journal)"`
-	OpenAIAPIKey    string `flag:"" env:"OPENAI_API_KEY" help:"OpenAI API key for transcription"`
+	Output      string `arg:"" optional:"" help:"Output file path"`
+	Name        string `flag:"" optional:"" help:"Working name (overrides git branch detection)"`
+	MaxDuration string `flag:"" default:"1h" help:"Max recording duration"`
+	MaxBytes    int64  `flag:"" default:"268435456" help:"Max file size (256MB)"`
+	Mode        string `flag:"" default:"memos" help:"Content mode: memos, journal or one defined in config.json"`
+	OutputDir   string `flag:"" optional:"" help:"Output dir (default: the mode's output_dir)"`
+	Language    string `flag:"" optional:"" help:"Spoken language, ISO-639-1 (default: auto-detect)"`
+	PostsDir    string `flag:"" default:"content/posts" help:"Existing posts for the glossary, tags and style"`
+	NoPreview   bool   `flag:"" help:"Disable live transcription preview while recording"`
+	NoCache     bool   `flag:"" help:"Always send audio to Whisper, bypassing the transcription cache"`
+	Translate   bool   `flag:"" help:"Translate a non-English memo into English before drafting"`
+	Social      bool   `flag:"" help:"Write posts for the social accounts in the Hugo config after the copy edit"`
+	HugoConfig  string `flag:"" default:"hugo.yaml" help:"Hugo config listing the social accounts under params.author"`
+	Offline     bool   `flag:"" help:"Use canned transcripts and drafts instead of the APIs, e.g. for demos"`
+
+	Author string   `flag:"" optional:"" help:"Author name for frontmatter (default: git user.name)"`
+	Tags   []string `flag:"" name:"tag" optional:"" help:"Tag the post must include (repeatable)"`
+
+	Exemplars      int `flag:"" optional:"" help:"Existing posts to show as style examples (2-3 recommended)"`
+	ExemplarTokens int `flag:"" default:"6000" help:"Token budget for style examples"`
+
+	DraftModel          string   `flag:"" optional:"" help:"Model for first drafts (default: provider's)"`
+	DraftMaxTokens      int64    `flag:"" optional:"" help:"Max tokens for first drafts (default: 4096)"`
+	DraftTemperature    *float64 `flag:"" optional:"" help:"Sampling temperature for first drafts"`
+	CopyEditModel       string   `flag:"" optional:"" help:"Model for copy edits (default: provider's)"`
+	CopyEditMaxTokens   int64    `flag:"" optional:"" help:"Max tokens for copy edits (default: 4096)"`
+	CopyEditTemperature *float64 `flag:"" optional:"" help:"Sampling temperature for copy edits"`
+
+	DraftChunkTokens int `flag:"" default:"8000" help:"Draft longer transcripts part by part (-1: never)"`
+
+	ProviderFlags `embed:""`
+
+	OpenAIAPIKey    string `flag:"" name:"openai-api-key" env:"OPENAI_API_KEY" help:"OpenAI API key for transcription"`
 	AnthropicAPIKey string `flag:"" env:"ANTHROPIC_API_KEY" help:"Anthropic API key for first draft"`
 }
 
+// ProviderFlags select the LLM provider for drafts and copy edits.
+type ProviderFlags struct {
+	Provider string `flag:"" default:"anthropic" enum:"anthropic,openai,openai-compatible" help:"LLM provider: ${enum}"`
+	BaseURL  string `flag:"" optional:"" help:"OpenAI-compatible endpoint (default: Ollama at localhost:11434)"`
+	LLMKey   string `flag:"" env:"LLM_API_KEY" name:"llm-api-key" help:"API key for an OpenAI-compatible provider"`
+	JSONMode bool   `flag:"" help:"Request JSON mode instead of function calls, for models without tools"`
+	Redact   bool   `flag:"" help:"Replace personal details with placeholders before text reaches the LLM"`
+}
+
+// newProvider creates the selected provider. Anthropic and OpenAI use their
+// own keys; an OpenAI-compatible endpoint uses --llm-api-key, if any. With
+// --redact or a mode that redacts, personal details are replaced before any
+// request is sent.
+func (f ProviderFlags) newProvider(anthropicKey, openAIKey string, mode content.ModeConfig) (content.Provider, error) {
+	config := content.ProviderConfig{Name: f.Provider, BaseURL: f.BaseURL, JSONMode: f.JSONMode}
+
+	switch f.Provider {
+	case content.ProviderAnthropic:
+		config.APIKey = anthropicKey
+	case content.ProviderOpenAI:
+		config.APIKey = openAIKey
+	default:
+		config.APIKey = f.LLMKey
+	}
+
+	provider, err := content.NewProvider(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LLM provider: %w", err)
+	}
+
+	if f.Redact || mode.Redact {
+		names, err := loadRedactionNames()
+		if err != nil {
+			return nil, err
+		}
+		provider = content.NewRedactingProvider(provider, content.NewRedactor(names))
+	}
+
+	return provider, nil
+}
+
+// loadRedactionNames reads the names to redact from the file under
+// workdir.Root(). A missing file yields no names, but one that cannot be
+// read is an error, so names are never sent unredacted.
+func loadRedactionNames() ([]string, error) {
+	path, err := workdir.RootFilePath(workdir.RedactNamesFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate redaction names: %w", err)
+	}
+
+	names, err := content.LoadRedactionNames(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load redaction names: %w", err)
+	}
+
+	return names, nil
+}
+
+// resolveKey returns value or, when empty, the key stored in the keychain.
+func resolveKey(value string, key keyring.APIKey) string {
+	if value != "" {
+		return value
+	}
+
+	secret, err := keyring.Get(key)
+	if err != nil {
+		slog.Debug("keychain lookup failed", "key", key, "error", err)
+		return ""
+	}
+
+	return secret
+}
+
 // Run executes the TUI command.
 //
 //nolint:funlen // CLI command with multiple setup steps
@@ -54,52 +161,29 @@ func (c *TUICmd) Run() error {
 
 	wg := sync.WaitGroup{}
 
-	// Parse and validate mode
-	mode := content.Mode(c.Mode)
-	if mode != content.ModeMemos && mode != content.ModeJournal {
-		return fmt.Errorf("invalid mode %q: must be 'memos' or 'journal'", c.Mode)
+	prompts, err := loadPrompts()
+	if err != nil {
+		return err
 	}
 
-	// Set mode-based default for OutputDir if not explicitly provided
-	if c.OutputDir == "" {
-		if mode == content.ModeMemos {
-			c.OutputDir = "content/posts"
-		} else {
-			c.OutputDir = "."
-		}
+	modes, mode, err := loadMode(c.Mode, prompts)
+	if err != nil {
+		return err
 	}
 
-	// Resolve API keys: environment variables take priority, fallback to keychain
-	if c.OpenAIAPIKey == "" {
-		if secret, err := keyring.Get(keyring.OpenAI); err == nil {
-			c.OpenAIAPIKey = secret
-		} else {
-			slog.Debug("keychain lookup failed", "key", "openai", "error", err)
-		}
+	// Set mode-based default for OutputDir if not explicitly provided
+	if c.OutputDir == "" {
+		c.OutputDir = mode.OutputDir
 	}
 
-	if c.AnthropicAPIKey == "" {
-		if secret, err := keyring.Get(keyring.Anthropic); err == nil {
-			c.AnthropicAPIKey = secret
-		} else {
-			slog.Debug("keychain lookup failed", "key", "anthropic", "error", err)
+	// Offline runs need neither keys nor a provider
+	var provider content.Provider
+	if !c.Offline {
+		if provider, err = c.onlineProvider(mode); err != nil {
+			return err
 		}
 	}
 
-	var missing []string
-	if c.OpenAIAPIKey == "" {
-		missing = append(missing, "openai")
-	}
-
-	if c.AnthropicAPIKey == "" {
-		missing = append(missing, "anthropic")
-	}
-
-	if len(missing) > 0 {
-		return fmt.Errorf("missing API keys: %s. Set via environment variables or run 'voice config set-key'",
-			strings.Join(missing, ", "))
-	}
-
 	// Determine working paths
 	workingName := getWorkingName(c.Name)
 
@@ -123,7 +207,7 @@ func (c *TUICmd) Run() error {
 		CaptureChannels: defaultChannels,
 	})
 
-	err := dev.CaptureInto(ctx, dataC)
+	err = dev.CaptureInto(ctx, dataC)
 	if err != nil {
 		return fmt.Errorf("failed to start audio capture: %w", err)
 	}
@@ -141,30 +225,94 @@ func (c *TUICmd) Run() error {
 		return fmt.Errorf("failed to determine output path: %w", err)
 	}
 
+	// Speech segmenter for the live transcript preview
+	var segmenter *audio.Segmenter
+	if !c.NoPreview {
+		segmenter, err = audio.NewSegmenter(audio.SegmenterConfig{
+			SampleRate: defaultSampleRate,
+			Channels:   defaultChannels,
+			Dir:        workdir.MustFilePath(workingName, workdir.SegmentsDir),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create speech segmenter: %w", err)
+		}
+	}
+
 	// Create audio file recorder
 	recorder, err := audio.NewRecorder(audio.Config{
 		SampleRate: defaultSampleRate,
 		Channels:   defaultChannels,
 		MP3Path:    outputPath,
+		Segmenter:  segmenter,
 	}, dataC)
 	if err != nil {
 		return fmt.Errorf("failed to create audio recorder: %w", err)
 	}
 
+	posts := loadPosts(c.PostsDir)
+	ledger := openUsageLedger(workingName, mode.Name)
+
 	// Build TUI config
 	config := tui.Config{
-		Cancel:          cancel,
-		WorkingName:     workingName,
-		OpenAIAPIKey:    c.OpenAIAPIKey,
-		AnthropicAPIKey: c.AnthropicAPIKey,
-		Mode:            mode,
-		MaxBytes:        c.MaxBytes,
-		EditorCmd:       os.Getenv("MEMOS_EDITOR"),
-		OutputDir:       c.OutputDir,
+		Cancel:       cancel,
+		WorkingName:  workingName,
+		OpenAIAPIKey: c.OpenAIAPIKey,
+		Provider:     provider,
+		Mode:         mode,
+		Tags:         content.NewTagIndex(posts),
+		MaxBytes:     c.MaxBytes,
+		EditorCmd:    os.Getenv("MEMOS_EDITOR"),
+		OutputDir:    c.OutputDir,
+		Translate:    c.Translate,
+		Usage:        ledger,
+		Offline:      c.Offline,
+		Generation: content.WriterConfig{
+			FirstDraft: content.GenerationParams{
+				Model:       c.DraftModel,
+				MaxTokens:   c.DraftMaxTokens,
+				Temperature: c.DraftTemperature,
+			},
+			CopyEdit: content.GenerationParams{
+				Model:       c.CopyEditModel,
+				MaxTokens:   c.CopyEditMaxTokens,
+				Temperature: c.CopyEditTemperature,
+			},
+			Prompts:    prompts,
+			PromptData: promptData(c.Author, c.Tags, posts),
+			Modes:      modes,
+			Exemplars:  selectExemplars(c.Exemplars, c.ExemplarTokens, c.Tags, posts),
+			Posts:      content.NewPostIndex(posts),
+
+			DraftChunkTokens: c.DraftChunkTokens,
+		},
+		TranscriptionHints: content.TranscriptionHints{
+			Language: c.Language,
+			Prompt:   loadGlossary(posts).Prompt(),
+		},
+	}
+
+	if c.Social {
+		platforms, err := content.LoadSocialPlatforms(c.HugoConfig)
+		if err != nil {
+			return fmt.Errorf("failed to load social accounts: %w", err)
+		}
+		config.Social = platforms
+	}
+
+	// Canned transcripts must not end up in the cache
+	if !c.NoCache && !c.Offline {
+		if dir, err := workdir.RootFilePath(workdir.TranscriptCacheDir); err == nil {
+			config.TranscriptCacheDir = dir
+		} else {
+			slog.Warn("transcription cache disabled", "error", err)
+		}
 	}
 
 	ctrls := makeRecordingControls(ctx, dev, recorder, dataC, c.MaxBytes)
-	p := tea.NewProgram(tui.New(config, ctrls))
+	if segmenter != nil {
+		ctrls.Segments = speechSegments(segmenter.Segments())
+	}
+	p := tea.NewProgram(tui.New(ctx, config, ctrls))
 
 	// Audio recorder goroutine (waits for channel close, MP3 conversion, cleanup)
 	wg.Go(func() {
@@ -184,18 +332,62 @@ func (c *TUICmd) Run() error {
 		return fmt.Errorf("failed to start TUI: %w", err)
 	}
 
+	// Abort any request still in flight when the TUI exits
+	cancel()
+
 	wg.Wait()
 
+	printSessionCost(ledger)
 	fmt.Println("\nfinished. bye!")
 
 	return nil
 }
 
+// onlineProvider resolves the API keys, environment variables taking
+// priority over the keychain, and creates the LLM provider. Transcription
+// always needs OpenAI; Anthropic only when it writes the drafts.
+func (c *TUICmd) onlineProvider(mode content.ModeConfig) (content.Provider, error) {
+	c.OpenAIAPIKey = resolveKey(c.OpenAIAPIKey, keyring.OpenAI)
+
+	var missing []string
+	if c.OpenAIAPIKey == "" {
+		missing = append(missing, "openai")
+	}
+
+	if c.Provider == content.ProviderAnthropic {
+		c.AnthropicAPIKey = resolveKey(c.AnthropicAPIKey, keyring.Anthropic)
+		if c.AnthropicAPIKey == "" {
+			missing = append(missing, "anthropic")
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing API keys: %s. Set via environment variables or run 'voice config set-key'",
+			strings.Join(missing, ", "))
+	}
+
+	return c.newProvider(c.AnthropicAPIKey, c.OpenAIAPIKey, mode)
+}
+
 // CopyEditCmd copy-edits a markdown file in place.
 type CopyEditCmd struct {
 	File            string `arg:"" required:"" help:"Path to markdown file"`
-	Mode            string `flag:"" default:"memos" help:"Content mode: memos (full) or journal (minimal)"`
+	Mode            string `flag:"" default:"memos" help:"Content mode: memos, journal or one defined in config.json"`
 	AnthropicAPIKey string `flag:"" env:"ANTHROPIC_API_KEY" help:"Anthropic API key for copy edit"`
+	OpenAIAPIKey    string `flag:"" name:"openai-api-key" env:"OPENAI_API_KEY" help:"OpenAI key for --provider openai"`
+
+	ProviderFlags `embed:""`
+
+	CopyEditModel       string   `flag:"" optional:"" aliases:"model" help:"Model for copy edits (default: provider's)"`
+	CopyEditMaxTokens   int64    `flag:"" optional:"" aliases:"max-tokens" help:"Max tokens for copy edits"`
+	CopyEditTemperature *float64 `flag:"" optional:"" aliases:"temperature" help:"Sampling temperature for copy edits"`
+
+	Author   string   `flag:"" optional:"" help:"Author name for frontmatter (default: git user.name)"`
+	Tags     []string `flag:"" name:"tag" optional:"" help:"Tag the post must include (repeatable)"`
+	PostsDir string   `flag:"" default:"content/posts" help:"Existing posts whose tags and style are reused"`
+
+	Exemplars      int `flag:"" optional:"" help:"Existing posts to show as style examples (2-3 recommended)"`
+	ExemplarTokens int `flag:"" default:"6000" help:"Token budget for style examples"`
 }
 
 // Run executes the copy-edit command.
@@ -205,34 +397,69 @@ func (c *CopyEditCmd) Run() error {
 		return fmt.Errorf("file not found: %w", err)
 	}
 
-	// Parse and validate mode
-	mode := content.Mode(c.Mode)
-	if mode != content.ModeMemos && mode != content.ModeJournal {
-		return fmt.Errorf("invalid mode %q: must be 'memos' or 'journal'", c.Mode)
+	prompts, err := loadPrompts()
+	if err != nil {
+		return err
 	}
 
-	// Resolve API key: environment variable takes priority, fallback to keychain
-	if c.AnthropicAPIKey == "" {
-		if secret, err := keyring.Get(keyring.Anthropic); err == nil {
-			c.AnthropicAPIKey = secret
-		} else {
-			slog.Debug("keychain lookup failed", "key", "anthropic", "error", err)
+	modes, mode, err := loadMode(c.Mode, prompts)
+	if err != nil {
+		return err
+	}
+
+	if !mode.Runs(content.PhaseCopyEdit) {
+		return fmt.Errorf("mode %s does not copy edit", mode.Name)
+	}
+
+	// Resolve the provider's API key: environment variable takes priority, fallback to keychain
+	switch c.Provider {
+	case content.ProviderAnthropic:
+		c.AnthropicAPIKey = resolveKey(c.AnthropicAPIKey, keyring.Anthropic)
+		if c.AnthropicAPIKey == "" {
+			return fmt.Errorf(
+				"missing Anthropic API key: set ANTHROPIC_API_KEY or run 'voice config set-key anthropic <key>'",
+			)
+		}
+	case content.ProviderOpenAI:
+		c.OpenAIAPIKey = resolveKey(c.OpenAIAPIKey, keyring.OpenAI)
+		if c.OpenAIAPIKey == "" {
+			return fmt.Errorf(
+				"missing OpenAI API key: set OPENAI_API_KEY or run 'voice config set-key openai <key>'",
+			)
 		}
 	}
 
-	if c.AnthropicAPIKey == "" {
-		return fmt.Errorf(
-			"missing Anthropic API key: set ANTHROPIC_API_KEY or run 'voice config set-key anthropic <key>'",
-		)
+	provider, err := c.newProvider(c.AnthropicAPIKey, c.OpenAIAPIKey, mode)
+	if err != nil {
+		return err
 	}
 
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
 	// Run TUI with copy-edit file phase
-	writer := content.NewWriter(c.AnthropicAPIKey)
-	p := tea.NewProgram(workflow.NewCopyEditFilePhase(writer, c.File, mode))
+	posts := loadPosts(c.PostsDir)
+	ledger := openUsageLedger(strings.TrimSuffix(filepath.Base(c.File), filepath.Ext(c.File)), mode.Name)
+	writer := content.NewWriter(provider, content.WriterConfig{
+		CopyEdit: content.GenerationParams{
+			Model:       c.CopyEditModel,
+			MaxTokens:   c.CopyEditMaxTokens,
+			Temperature: c.CopyEditTemperature,
+		},
+		Prompts:    prompts,
+		PromptData: promptData(c.Author, c.Tags, posts),
+		Modes:      modes,
+		Exemplars:  selectExemplars(c.Exemplars, c.ExemplarTokens, c.Tags, posts),
+		Posts:      content.NewPostIndex(posts),
+		Usage:      ledger,
+	})
+	p := tea.NewProgram(workflow.NewCopyEditFilePhase(ctx, writer, c.File, mode, content.NewTagIndex(posts)))
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("failed to run copy-edit TUI: %w", err)
 	}
 
+	printSessionCost(ledger)
+
 	return nil
 }
 
@@ -318,6 +545,232 @@ func (c *ListKeysCmd) Run() error {
 	return nil
 }
 
+// PromptsCmd groups prompt template subcommands.
+type PromptsCmd struct {
+	Dump PromptsDumpCmd `cmd:"" help:"Write the built-in prompt templates for customization"`
+}
+
+// PromptsDumpCmd writes the built-in prompt templates to the prompts directory.
+type PromptsDumpCmd struct {
+	Dir   string `flag:"" optional:"" help:"Target directory (default: prompts/ under the memos root)"`
+	Force bool   `flag:"" help:"Overwrite templates that already exist"`
+}
+
+// Run executes the prompts dump command.
+func (c *PromptsDumpCmd) Run() error {
+	dir := c.Dir
+	if dir == "" {
+		var err error
+		if dir, err = workdir.RootFilePath(workdir.PromptsDir); err != nil {
+			return fmt.Errorf("failed to locate prompts directory: %w", err)
+		}
+	}
+
+	written, err := content.DumpPrompts(dir, c.Force)
+	if err != nil {
+		return fmt.Errorf("failed to dump prompt templates: %w", err)
+	}
+
+	for _, path := range written {
+		fmt.Printf("wrote %s\n", path)
+	}
+
+	if skipped := len(content.PromptNames()) - len(written); skipped > 0 {
+		fmt.Printf("kept %d existing template(s); use --force to overwrite\n", skipped)
+	}
+
+	fmt.Println("\nTemplates use text/template. Available variables:")
+	fmt.Println("  .Author .Date .Mode .Frontmatter .Tags .ExistingTags  (join lists with {{join .Tags \", \"}})")
+
+	return nil
+}
+
+// CacheCmd groups transcription cache subcommands.
+type CacheCmd struct {
+	Stats CacheStatsCmd `cmd:"" help:"Show transcription cache size"`
+	Prune CachePruneCmd `cmd:"" help:"Remove cached transcripts"`
+}
+
+// CacheStatsCmd reports the transcription cache contents.
+type CacheStatsCmd struct{}
+
+// Run executes the cache stats command.
+func (c *CacheStatsCmd) Run() error {
+	cache, dir, err := openTranscriptCache()
+	if err != nil {
+		return err
+	}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		return fmt.Errorf("failed to read transcription cache: %w", err)
+	}
+
+	fmt.Printf("Location: %s\n", dir)
+	fmt.Printf("Entries:  %d\n", stats.Entries)
+	fmt.Printf("Size:     %.1f KiB\n", float64(stats.Bytes)/1024)
+
+	if stats.Entries > 0 {
+		fmt.Printf("Oldest:   %s\n", stats.Oldest.Format(time.DateTime))
+		fmt.Printf("Newest:   %s\n", stats.Newest.Format(time.DateTime))
+	}
+
+	return nil
+}
+
+// CachePruneCmd removes cached transcripts.
+type CachePruneCmd struct {
+	OlderThan string `flag:"" optional:"" help:"Only remove entries unused this long, e.g. 30d or 12h (default: all)"`
+}
+
+// Run executes the cache prune command.
+func (c *CachePruneCmd) Run() error {
+	var olderThan time.Duration
+	if c.OlderThan != "" {
+		var err error
+		if olderThan, err = parseAge(c.OlderThan); err != nil {
+			return err
+		}
+	}
+
+	cache, _, err := openTranscriptCache()
+	if err != nil {
+		return err
+	}
+
+	removed, err := cache.Prune(olderThan)
+	if err != nil {
+		return fmt.Errorf("failed to prune transcription cache: %w", err)
+	}
+
+	fmt.Printf("Removed %d cached transcript(s)\n", removed)
+
+	return nil
+}
+
+func openTranscriptCache() (*content.TranscriptCache, string, error) {
+	dir, err := workdir.RootFilePath(workdir.TranscriptCacheDir)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to locate transcription cache: %w", err)
+	}
+
+	return content.NewTranscriptCache(dir), dir, nil
+}
+
+// parseAge parses a duration that may also use a day suffix, e.g. "30d".
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q: expected e.g. 30d", s)
+		}
+
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+
+	return d, nil
+}
+
+// UsageCmd reports token usage and estimated cost from the usage ledger.
+type UsageCmd struct {
+	Since string `flag:"" default:"30d" help:"Only count requests this recent, e.g. 30d or 12h"`
+	By    string `flag:"" default:"model" enum:"mode,model,phase" help:"Group totals by mode, model or phase"`
+}
+
+// Run executes the usage command.
+func (c *UsageCmd) Run() error {
+	age, err := parseAge(c.Since)
+	if err != nil {
+		return err
+	}
+
+	path, err := workdir.RootFilePath(workdir.UsageLedgerFile)
+	if err != nil {
+		return fmt.Errorf("failed to locate usage ledger: %w", err)
+	}
+
+	since := time.Now().Add(-age)
+	records, err := content.ReadUsage(path, since)
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		fmt.Printf("No usage recorded since %s\n", since.Format(time.DateOnly))
+		return nil
+	}
+
+	groups, err := content.SummarizeUsage(records, c.By)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Usage since %s\n\n", since.Format(time.DateOnly))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tREQUESTS\tINPUT\tOUTPUT\tCACHE HIT\tCACHE MISS\tAUDIO\tCOST\n", strings.ToUpper(c.By))
+
+	for _, group := range groups {
+		printUsageRow(w, group.Key, group)
+	}
+
+	var total content.UsageTotals
+	for _, record := range records {
+		total.Add(record)
+	}
+	printUsageRow(w, "total", total)
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to print usage: %w", err)
+	}
+
+	fmt.Println("\nCosts are estimates from list prices; models without a known price count as $0.")
+
+	return nil
+}
+
+func printUsageRow(w io.Writer, key string, totals content.UsageTotals) {
+	input, output, cacheHit, cacheMiss, audio := "-", "-", "-", "-", "-"
+	if totals.InputTokens > 0 || totals.OutputTokens > 0 {
+		input = content.FormatCount(totals.InputTokens)
+		output = content.FormatCount(totals.OutputTokens)
+	}
+	if totals.CacheReadTokens > 0 || totals.CacheWriteTokens > 0 {
+		cacheHit = content.FormatCount(totals.CacheReadTokens)
+		cacheMiss = content.FormatCount(totals.CacheWriteTokens)
+	}
+	if totals.AudioSeconds > 0 {
+		audio = content.FormatAudio(totals.AudioSeconds)
+	}
+
+	fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t$%.4f\n",
+		key, totals.Requests, input, output, cacheHit, cacheMiss, audio, totals.Cost)
+}
+
+// openUsageLedger returns the ledger recording this session's requests, or
+// nil when its location cannot be determined.
+func openUsageLedger(session string, mode content.Mode) *content.UsageLedger {
+	path, err := workdir.RootFilePath(workdir.UsageLedgerFile)
+	if err != nil {
+		slog.Warn("usage ledger disabled", "error", err)
+		return nil
+	}
+
+	return content.NewUsageLedger(path, session, mode)
+}
+
+// printSessionCost prints what the session's requests cost.
+func printSessionCost(ledger *content.UsageLedger) {
+	if totals := ledger.Session(); totals.Requests > 0 {
+		fmt.Printf("\nSession cost: %s\n", totals)
+	}
+}
+
 func main() {
 	// Set up text-based logger for CLI output
 	//nolint:exhaustruct // Using default values for other HandlerOptions fields
@@ -328,7 +781,13 @@ func main() {
 	slog.SetDefault(logger)
 
 	cli := &CLI{} //nolint:exhaustruct // Kong fills in command fields
-	ctx := kong.Parse(cli)
+	var options []kong.Option
+	if path, err := workdir.RootFilePath(workdir.ConfigFile); err == nil {
+		// Flag defaults, e.g. {"draft_model": "claude-haiku-4-5"}; a missing file is ignored
+		options = append(options, kong.Configuration(kong.JSON, path))
+	}
+
+	ctx := kong.Parse(cli, options...)
 	err := ctx.Run()
 	ctx.FatalIfErrorf(err)
 	os.Exit(0)
@@ -352,6 +811,124 @@ func getWorkingName(explicitName string) string {
 	return time.Now().Format(time.DateOnly)
 }
 
+// loadPosts reads existing posts used to seed the glossary and tag
+// suggestions. Failures are logged and yield no posts.
+func loadPosts(postsDir string) []content.Post {
+	if postsDir == "" {
+		return nil
+	}
+
+	posts, err := content.LoadPosts(postsDir)
+	if err != nil {
+		slog.Debug("failed to load posts", "dir", postsDir, "error", err)
+		return nil
+	}
+
+	return posts
+}
+
+// loadGlossary merges the user glossary under workdir.Root() with terms
+// harvested from existing posts. Failures are logged and yield fewer terms
+// rather than blocking a recording session.
+func loadGlossary(posts []content.Post) *content.Glossary {
+	glossary := &content.Glossary{}
+
+	if path, err := workdir.RootFilePath(workdir.GlossaryFile); err == nil {
+		userGlossary, err := content.LoadGlossary(path)
+		if err != nil {
+			slog.Warn("failed to load glossary", "path", path, "error", err)
+		} else {
+			glossary.Merge(userGlossary)
+		}
+	}
+
+	glossary.Merge(content.GlossaryFromPosts(posts))
+
+	return glossary
+}
+
+// loadPrompts returns the built-in prompts overridden by templates in the
+// prompts directory under workdir.Root().
+func loadPrompts() (*content.Prompts, error) {
+	dir, err := workdir.RootFilePath(workdir.PromptsDir)
+	if err != nil {
+		slog.Debug("prompt overrides unavailable", "error", err)
+		return content.DefaultPrompts(), nil
+	}
+
+	prompts, err := content.LoadPrompts(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prompt templates: %w", err)
+	}
+
+	return prompts, nil
+}
+
+// loadMode returns the modes from the config file under workdir.Root() and
+// the named one, checked against the available prompts. Prompt templates no
+// mode uses are rejected.
+func loadMode(name string, prompts *content.Prompts) (content.Modes, content.ModeConfig, error) {
+	modes := content.DefaultModes()
+	if path, err := workdir.RootFilePath(workdir.ConfigFile); err == nil {
+		if modes, err = content.LoadModes(path); err != nil {
+			return nil, content.ModeConfig{}, fmt.Errorf("failed to load modes: %w", err)
+		}
+	}
+
+	if err := modes.CheckPrompts(prompts); err != nil {
+		return nil, content.ModeConfig{}, fmt.Errorf("invalid prompt templates: %w", err)
+	}
+
+	mode, err := modes.Lookup(content.Mode(name))
+	if err != nil {
+		return nil, content.ModeConfig{}, fmt.Errorf("failed to select mode: %w", err)
+	}
+
+	if err := mode.Validate(prompts); err != nil {
+		return nil, content.ModeConfig{}, fmt.Errorf("invalid mode configuration: %w", err)
+	}
+
+	return modes, mode, nil
+}
+
+// promptData fills prompt template variables. The author defaults to the
+// git user name.
+func promptData(author string, tags []string, posts []content.Post) content.PromptData {
+	if author == "" {
+		if name, err := git.GetUserName(); err == nil {
+			author = name
+		} else {
+			slog.Debug("no author configured", "error", err)
+		}
+	}
+
+	return content.PromptData{
+		Author:       author,
+		Tags:         tags,
+		ExistingTags: content.ExistingTags(posts),
+	}
+}
+
+// selectExemplars picks up to count posts as style examples within the
+// token budget, preferring posts that share tags.
+func selectExemplars(count, tokenBudget int, tags []string, posts []content.Post) []content.Post {
+	exemplars := content.SelectExemplars(posts, content.ExemplarOptions{
+		Count:       count,
+		TokenBudget: tokenBudget,
+		Tags:        tags,
+	})
+
+	if count > 0 {
+		titles := make([]string, 0, len(exemplars))
+		for _, post := range exemplars {
+			titles = append(titles, post.Frontmatter.Title)
+		}
+		slog.Info("Using posts as style examples", "posts", titles)
+	}
+
+	return exemplars
+}
+
 func makeRecordingControls(
 	ctx context.Context,
 	dev audio.Device,
@@ -381,6 +958,25 @@ func makeRecordingControls(
 	}
 }
 
+// speechSegments adapts audio segments to the workflow's segment type.
+func speechSegments(in <-chan audio.Segment) <-chan workflow.SpeechSegment {
+	out := make(chan workflow.SpeechSegment, cap(in))
+
+	go func() {
+		defer close(out)
+
+		for seg := range in {
+			out <- workflow.SpeechSegment{
+				Path:       seg.Path,
+				Final:      seg.Final,
+				Incomplete: seg.Incomplete,
+			}
+		}
+	}()
+
+	return out
+}
+
 type audioDevKnob struct {
 	ctx context.Context
 	dev audio.Device
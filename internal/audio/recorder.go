@@ -29,6 +29,7 @@ type Recorder struct {
 	pcmFile      *os.File
 	bytesWritten int64
 	sampleBuffer *SampleRingBuffer
+	segmenter    *Segmenter
 	mu           sync.RWMutex
 	wg           sync.WaitGroup
 	errOnce      sync.Once
@@ -40,6 +41,10 @@ type Config struct {
 	SampleRate int    // Sample rate in Hz (e.g., 16000)
 	Channels   int    // Number of channels (1 for mono, 2 for stereo)
 	MP3Path    string // Final MP3 output path
+
+	// Segmenter, when set, receives every captured sample and is closed
+	// when recording ends (optional)
+	Segmenter *Segmenter
 }
 
 // NewRecorder creates a new audio file recorder.
@@ -76,6 +81,7 @@ func NewRecorder(config Config, input <-chan []byte) (*Recorder, error) {
 		pcmPath:      pcmPath,
 		mp3Path:      config.MP3Path,
 		sampleBuffer: NewSampleRingBuffer(DefaultSampleBufferCapacity),
+		segmenter:    config.Segmenter,
 	}, nil
 }
 
@@ -96,6 +102,11 @@ func (r *Recorder) Start(ctx context.Context) error {
 
 	r.wg.Go(func() {
 		defer func() {
+			// Emit the leftover tail before the slower MP3 conversion
+			if r.segmenter != nil {
+				r.segmenter.Close()
+			}
+
 			// Close PCM file
 			if err := r.pcmFile.Close(); err != nil {
 				r.setError(fmt.Errorf("failed to close PCM file: %w", err))
@@ -140,6 +151,10 @@ func (r *Recorder) Start(ctx context.Context) error {
 				samples := BytesToInt16(data)
 				r.sampleBuffer.Write(samples)
 
+				if r.segmenter != nil {
+					r.segmenter.Write(samples)
+				}
+
 			case <-ctx.Done():
 				return
 			}
@@ -0,0 +1,269 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DefaultMinSegment is the shortest segment cut at a pause.
+	DefaultMinSegment = 15 * time.Second
+	// DefaultMaxSegment forces a cut even without a pause.
+	DefaultMaxSegment = 60 * time.Second
+	// DefaultMinSilence is how long a pause must last to end a segment.
+	DefaultMinSilence = 600 * time.Millisecond
+	// DefaultSilenceThreshold is the RMS level (of int16 full scale) below which a frame is silent.
+	DefaultSilenceThreshold = 500
+	// DefaultMinTail is the shortest leftover tail worth transcribing.
+	DefaultMinTail = 500 * time.Millisecond
+
+	// segmentFrame is the window over which loudness is measured.
+	segmentFrame = 20 * time.Millisecond
+	// segmentQueueSize bounds completed segments awaiting a consumer.
+	segmentQueueSize = 256
+)
+
+// Segment is a WAV file holding a stretch of speech cut from the capture stream.
+type Segment struct {
+	// Index orders segments from 0.
+	Index int
+	// Path is the WAV file. Empty for a final segment without a usable tail.
+	Path string
+	// Duration of the audio in the file.
+	Duration time.Duration
+	// Final marks the leftover tail emitted when recording finishes.
+	Final bool
+	// Incomplete is set on the final segment when earlier segments were dropped.
+	Incomplete bool
+}
+
+// SegmenterConfig configures speech segmentation.
+type SegmenterConfig struct {
+	SampleRate int
+	Channels   int
+	// Dir receives the segment WAV files. Existing segment files are removed.
+	Dir string
+
+	MinSegment       time.Duration
+	MaxSegment       time.Duration
+	MinSilence       time.Duration
+	SilenceThreshold float64
+	MinTail          time.Duration
+}
+
+// WithDefaults returns a config with default values applied to zero fields.
+func (c SegmenterConfig) WithDefaults() SegmenterConfig {
+	if c.SampleRate == 0 {
+		c.SampleRate = DefaultSampleRate
+	}
+
+	if c.Channels == 0 {
+		c.Channels = DefaultChannels
+	}
+
+	if c.MinSegment == 0 {
+		c.MinSegment = DefaultMinSegment
+	}
+
+	if c.MaxSegment == 0 {
+		c.MaxSegment = DefaultMaxSegment
+	}
+
+	if c.MinSilence == 0 {
+		c.MinSilence = DefaultMinSilence
+	}
+
+	if c.SilenceThreshold == 0 {
+		c.SilenceThreshold = DefaultSilenceThreshold
+	}
+
+	if c.MinTail == 0 {
+		c.MinTail = DefaultMinTail
+	}
+
+	return c
+}
+
+// Segmenter cuts the capture stream into speech segments at pauses, so they
+// can be transcribed while recording continues.
+//
+// Write and Close must be called from a single goroutine (the recorder's).
+// Completed segments are delivered on Segments; the channel is closed after
+// the final segment.
+type Segmenter struct {
+	config SegmenterConfig
+	out    chan Segment
+
+	pending    []int16
+	frame      []int16
+	silentRun  int // consecutive silent samples at the end of pending
+	next       int
+	dropped    bool
+	closed     bool
+	frameSize  int
+	minSegment int
+	maxSegment int
+	minSilence int
+	minTail    int
+}
+
+// NewSegmenter creates a segmenter writing WAV files to config.Dir.
+func NewSegmenter(config SegmenterConfig) (*Segmenter, error) {
+	config = config.WithDefaults()
+
+	if config.Dir == "" {
+		return nil, errors.New("segment directory cannot be empty")
+	}
+
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create segment directory %s: %w", config.Dir, err)
+	}
+
+	// Segments from an earlier session would be mistaken for this one's
+	stale, err := filepath.Glob(filepath.Join(config.Dir, "segment-*.wav"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale segments: %w", err)
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale segment %s: %w", path, err)
+		}
+	}
+
+	samplesPer := func(d time.Duration) int {
+		return int(d.Seconds() * float64(config.SampleRate*config.Channels))
+	}
+
+	return &Segmenter{
+		config:     config,
+		out:        make(chan Segment, segmentQueueSize),
+		frameSize:  samplesPer(segmentFrame),
+		minSegment: samplesPer(config.MinSegment),
+		maxSegment: samplesPer(config.MaxSegment),
+		minSilence: samplesPer(config.MinSilence),
+		minTail:    samplesPer(config.MinTail),
+	}, nil
+}
+
+// Segments returns the channel of completed segments.
+func (s *Segmenter) Segments() <-chan Segment {
+	return s.out
+}
+
+// Write appends captured samples, cutting a segment at the first long enough
+// pause once the minimum segment length is reached.
+func (s *Segmenter) Write(samples []int16) {
+	if s.closed {
+		return
+	}
+
+	for len(samples) > 0 {
+		n := min(s.frameSize-len(s.frame), len(samples))
+		s.frame = append(s.frame, samples[:n]...)
+		samples = samples[n:]
+
+		if len(s.frame) < s.frameSize {
+			return
+		}
+
+		s.addFrame(s.frame)
+		s.frame = s.frame[:0]
+	}
+}
+
+func (s *Segmenter) addFrame(frame []int16) {
+	s.pending = append(s.pending, frame...)
+
+	if rms(frame) < s.config.SilenceThreshold {
+		s.silentRun += len(frame)
+	} else {
+		s.silentRun = 0
+	}
+
+	paused := s.silentRun >= s.minSilence && len(s.pending) >= s.minSegment
+	if paused || len(s.pending) >= s.maxSegment {
+		s.cut(false)
+	}
+}
+
+// Close emits the leftover audio as the final segment and closes Segments.
+func (s *Segmenter) Close() {
+	if s.closed {
+		return
+	}
+
+	s.pending = append(s.pending, s.frame...)
+	s.frame = nil
+	s.cut(true)
+	s.closed = true
+	close(s.out)
+}
+
+// cut writes pending samples to a segment file and emits it.
+func (s *Segmenter) cut(final bool) {
+	seg := Segment{
+		Index:      s.next,
+		Final:      final,
+		Incomplete: final && s.dropped,
+	}
+	s.next++
+
+	samples := s.pending
+	s.pending = nil
+	s.silentRun = 0
+
+	if len(samples) >= s.minTail || (!final && len(samples) > 0) {
+		path := filepath.Join(s.config.Dir, fmt.Sprintf("segment-%03d.wav", seg.Index))
+		if err := s.writeFile(path, samples); err != nil {
+			slog.Warn("failed to write speech segment", "path", path, "error", err)
+			s.dropped = true
+			seg.Incomplete = final
+		} else {
+			seg.Path = path
+			seg.Duration = time.Duration(float64(len(samples)) /
+				float64(s.config.SampleRate*s.config.Channels) * float64(time.Second))
+		}
+	}
+
+	if seg.Path == "" && !final {
+		return
+	}
+
+	// Never block the recorder; a consumer that falls behind loses coverage
+	select {
+	case s.out <- seg:
+	default:
+		s.dropped = true
+		slog.Warn("speech segment queue full, dropping segment", "index", seg.Index)
+	}
+}
+
+func (s *Segmenter) writeFile(path string, samples []int16) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create segment file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return WriteWAV(file, samples, s.config.SampleRate, s.config.Channels)
+}
+
+// rms returns the root mean square level of samples.
+func rms(samples []int16) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, sample := range samples {
+		v := float64(sample)
+		sum += v * v
+	}
+
+	return math.Sqrt(sum / float64(len(samples)))
+}
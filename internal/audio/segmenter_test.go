@@ -0,0 +1,123 @@
+package audio_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alkime/memos/internal/audio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSampleRate = 1000
+
+func tone(d time.Duration) []int16 {
+	samples := make([]int16, int(d.Seconds()*testSampleRate))
+	for i := range samples {
+		if i%2 == 0 {
+			samples[i] = 4000
+		} else {
+			samples[i] = -4000
+		}
+	}
+	return samples
+}
+
+func silence(d time.Duration) []int16 {
+	return make([]int16, int(d.Seconds()*testSampleRate))
+}
+
+func newTestSegmenter(t *testing.T, dir string) *audio.Segmenter {
+	t.Helper()
+
+	seg, err := audio.NewSegmenter(audio.SegmenterConfig{
+		SampleRate: testSampleRate,
+		Channels:   1,
+		Dir:        dir,
+		MinSegment: 2 * time.Second,
+		MaxSegment: 5 * time.Second,
+		MinSilence: 500 * time.Millisecond,
+		MinTail:    200 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	return seg
+}
+
+func drain(seg *audio.Segmenter) []audio.Segment {
+	var segments []audio.Segment
+	for s := range seg.Segments() {
+		segments = append(segments, s)
+	}
+	return segments
+}
+
+func TestSegmenter_CutsAtPauses(t *testing.T) {
+	dir := t.TempDir()
+	seg := newTestSegmenter(t, dir)
+
+	// A short pause before the minimum length does not cut
+	seg.Write(tone(time.Second))
+	seg.Write(silence(600 * time.Millisecond))
+	seg.Write(tone(time.Second))
+	// A pause after the minimum length does
+	seg.Write(silence(600 * time.Millisecond))
+	seg.Write(tone(time.Second))
+	seg.Close()
+
+	segments := drain(seg)
+	require.Len(t, segments, 2)
+
+	assert.Equal(t, 0, segments[0].Index)
+	assert.False(t, segments[0].Final)
+	assert.Equal(t, 3100*time.Millisecond, segments[0].Duration)
+
+	assert.True(t, segments[1].Final)
+	assert.False(t, segments[1].Incomplete)
+	assert.Equal(t, 1100*time.Millisecond, segments[1].Duration)
+
+	for _, s := range segments {
+		info, err := os.Stat(s.Path)
+		require.NoError(t, err)
+		assert.Equal(t, int64(44+s.Duration.Milliseconds()*2), info.Size(), "WAV header plus samples")
+	}
+}
+
+func TestSegmenter_ForcesCutAtMaxSegment(t *testing.T) {
+	seg := newTestSegmenter(t, t.TempDir())
+
+	seg.Write(tone(6 * time.Second))
+	seg.Close()
+
+	segments := drain(seg)
+	require.Len(t, segments, 2)
+	assert.Equal(t, 5*time.Second, segments[0].Duration)
+	assert.True(t, segments[1].Final)
+}
+
+func TestSegmenter_ShortTailIsSkipped(t *testing.T) {
+	seg := newTestSegmenter(t, t.TempDir())
+
+	seg.Write(tone(3 * time.Second))
+	seg.Write(silence(500 * time.Millisecond))
+	seg.Write(tone(100 * time.Millisecond))
+	seg.Close()
+
+	segments := drain(seg)
+	require.Len(t, segments, 2)
+	assert.True(t, segments[1].Final)
+	assert.Empty(t, segments[1].Path, "tail shorter than MinTail has no file")
+}
+
+func TestNewSegmenter_RemovesStaleSegments(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "segment-007.wav")
+	require.NoError(t, os.WriteFile(stale, []byte("old"), 0o600))
+
+	_ = newTestSegmenter(t, dir)
+
+	_, err := os.Stat(stale)
+	assert.True(t, os.IsNotExist(err))
+}
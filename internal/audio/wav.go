@@ -0,0 +1,41 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// wavHeaderSize is the size of a canonical 16-bit PCM WAV header.
+const wavHeaderSize = 44
+
+// WriteWAV writes S16LE samples as a 16-bit PCM WAV file.
+func WriteWAV(w io.Writer, samples []int16, sampleRate, channels int) error {
+	dataSize := len(samples) * 2
+	blockAlign := channels * 2
+
+	header := make([]byte, wavHeaderSize)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(wavHeaderSize-8+dataSize))
+	copy(header[8:12], "WAVE")
+	copy(header[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:20], 16) // fmt chunk size
+	binary.LittleEndian.PutUint16(header[20:22], 1)  // PCM
+	binary.LittleEndian.PutUint16(header[22:24], uint16(channels))
+	binary.LittleEndian.PutUint32(header[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(header[28:32], uint32(sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(header[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(header[34:36], 16) // bits per sample
+	copy(header[36:40], "data")
+	binary.LittleEndian.PutUint32(header[40:44], uint32(dataSize))
+
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write WAV header: %w", err)
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, samples); err != nil {
+		return fmt.Errorf("failed to write WAV samples: %w", err)
+	}
+
+	return nil
+}
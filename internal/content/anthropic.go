@@ -0,0 +1,181 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+)
+
+// DefaultModel is the Anthropic model used by phases without a configured
+// model.
+const DefaultModel = string(anthropic.ModelClaudeSonnet4_5_20250929)
+
+// AnthropicProvider sends requests to the Anthropic Messages API.
+type AnthropicProvider struct {
+	apiKey string
+}
+
+// NewAnthropicProvider creates a provider for the Anthropic Messages API.
+func NewAnthropicProvider(apiKey string) *AnthropicProvider {
+	return &AnthropicProvider{apiKey: apiKey}
+}
+
+// Name implements Provider.
+func (p *AnthropicProvider) Name() string { return "Anthropic API" }
+
+// DefaultModel implements Provider.
+func (p *AnthropicProvider) DefaultModel() string { return DefaultModel }
+
+// Validate implements Provider.
+func (p *AnthropicProvider) Validate() error {
+	if p.apiKey == "" {
+		return errors.New("API key required: set ANTHROPIC_API_KEY or use --anthropic-api-key")
+	}
+
+	return nil
+}
+
+// newClient creates an Anthropic client. SDK retries are disabled because
+// requests go through the writer's own retry policy.
+func (p *AnthropicProvider) newClient() anthropic.Client {
+	return anthropic.NewClient(option.WithAPIKey(p.apiKey), option.WithMaxRetries(0))
+}
+
+// Chat implements Provider.
+func (p *AnthropicProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	client := p.newClient()
+
+	resp, err := client.Messages.New(ctx, anthropicParams(req))
+	if err != nil {
+		return nil, fmt.Errorf("messages request failed: %w", err)
+	}
+
+	return anthropicResponse(resp), nil
+}
+
+// StreamChat implements Provider.
+func (p *AnthropicProvider) StreamChat(
+	ctx context.Context,
+	req ChatRequest,
+	onText func(text string),
+) (*ChatResponse, error) {
+	client := p.newClient()
+
+	stream := client.Messages.NewStreaming(ctx, anthropicParams(req))
+	defer stream.Close()
+
+	message := anthropic.Message{}
+	var text strings.Builder
+	if onText != nil {
+		onText("")
+	}
+
+	for stream.Next() {
+		event := stream.Current()
+		if err := message.Accumulate(event); err != nil {
+			return nil, fmt.Errorf("failed to accumulate message stream: %w", err)
+		}
+
+		delta, ok := event.AsAny().(anthropic.ContentBlockDeltaEvent)
+		if !ok || onText == nil {
+			continue
+		}
+
+		if textDelta, ok := delta.Delta.AsAny().(anthropic.TextDelta); ok {
+			text.WriteString(textDelta.Text)
+			onText(text.String())
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		return nil, fmt.Errorf("messages stream failed: %w", err)
+	}
+
+	return anthropicResponse(&message), nil
+}
+
+// anthropicParams converts a request to Messages API parameters. A trailing
+// assistant message is sent as a prefill the model continues. With
+// CacheSystem, every system block ends in a cache breakpoint, so the prompt
+// stays cached even when the exemplars after it change.
+func anthropicParams(req ChatRequest) anthropic.MessageNewParams {
+	params := anthropic.MessageNewParams{
+		Model:     anthropic.Model(req.Params.Model),
+		MaxTokens: req.Params.MaxTokens,
+	}
+	if req.Params.Temperature != nil {
+		params.Temperature = anthropic.Float(*req.Params.Temperature)
+	}
+
+	for _, block := range req.System {
+		param := anthropic.TextBlockParam{Text: block}
+		if req.CacheSystem {
+			param.CacheControl = anthropic.NewCacheControlEphemeralParam()
+		}
+		params.System = append(params.System, param)
+	}
+
+	for _, message := range req.Messages {
+		var block anthropic.ContentBlockParamUnion
+		switch {
+		case message.ToolCall != nil:
+			block = anthropic.NewToolUseBlock(message.ToolCall.ID, message.ToolCall.Input, message.ToolCall.Name)
+		case message.ToolResult != nil:
+			result := message.ToolResult
+			block = anthropic.NewToolResultBlock(result.CallID, result.Text, result.IsError)
+		default:
+			block = anthropic.NewTextBlock(message.Text)
+		}
+
+		if message.Assistant {
+			params.Messages = append(params.Messages, anthropic.NewAssistantMessage(block))
+		} else {
+			params.Messages = append(params.Messages, anthropic.NewUserMessage(block))
+		}
+	}
+
+	if req.Tool != nil {
+		tool := anthropic.ToolUnionParamOfTool(anthropic.ToolInputSchemaParam{
+			Properties: req.Tool.Properties,
+			Required:   req.Tool.Required,
+		}, req.Tool.Name)
+		tool.OfTool.Description = anthropic.String(req.Tool.Description)
+
+		params.Tools = []anthropic.ToolUnionParam{tool}
+		params.ToolChoice = anthropic.ToolChoiceParamOfTool(req.Tool.Name)
+	}
+
+	return params
+}
+
+// anthropicResponse converts a Messages API response. Text blocks are joined
+// and the first tool use becomes the tool call.
+func anthropicResponse(message *anthropic.Message) *ChatResponse {
+	resp := &ChatResponse{
+		Truncated:        message.StopReason == anthropic.StopReasonMaxTokens,
+		Model:            string(message.Model),
+		InputTokens:      message.Usage.InputTokens,
+		OutputTokens:     message.Usage.OutputTokens,
+		CacheReadTokens:  message.Usage.CacheReadInputTokens,
+		CacheWriteTokens: message.Usage.CacheCreationInputTokens,
+	}
+
+	var text strings.Builder
+	for _, block := range message.Content {
+		switch block := block.AsAny().(type) {
+		case anthropic.TextBlock:
+			text.WriteString(block.Text)
+		case anthropic.ToolUseBlock:
+			if resp.ToolCall == nil {
+				resp.ToolCall = &ToolCall{ID: block.ID, Name: block.Name, Input: block.Input}
+			}
+		}
+	}
+	resp.Text = text.String()
+
+	return resp
+}
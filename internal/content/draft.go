@@ -0,0 +1,212 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultDraftChunkTokens is the estimated transcript size above which first
+// drafts are written part by part.
+const DefaultDraftChunkTokens = 8000
+
+// maxDraftContinuations bounds the follow-up requests made when a draft is
+// cut off at the token limit.
+const maxDraftContinuations = 3
+
+// ErrTruncated reports a response cut off at the max token limit.
+var ErrTruncated = errors.New("response truncated at the max token limit")
+
+// truncationError reports a response that stopped at maxTokens, naming the
+// flag that raises the limit.
+func truncationError(what string, maxTokens int64, flag string) error {
+	return fmt.Errorf("%w: %s needs more than %d tokens, raise %s", ErrTruncated, what, maxTokens, flag)
+}
+
+// streamFunc sends a streaming request; see Writer.stream.
+type streamFunc func(ctx context.Context, req ChatRequest, onText func(text string)) (*ChatResponse, error)
+
+// continueDraft sends req and, while the response stops at the token
+// limit, asks the model to carry on from the text so far. onText, when
+// non-nil, receives the whole draft so far. Gives up with ErrTruncated after
+// maxDraftContinuations follow-ups.
+func continueDraft(
+	ctx context.Context,
+	req ChatRequest,
+	send streamFunc,
+	onText func(draft string),
+) (string, error) {
+	messages := req.Messages
+	draft := ""
+	// trimmed is the whitespace cut from the end of the draft so far
+	trimmed := ""
+
+	for continuation := 0; ; continuation++ {
+		prefix := draft
+		req.Messages = messages
+		if prefix != "" {
+			// The draft so far is sent as the assistant's reply to continue.
+			// Anthropic rejects a prefill ending in whitespace, so it is
+			// trimmed and the model writes it again.
+			req.Messages = append(messages[:len(messages):len(messages)], ChatMessage{Assistant: true, Text: prefix})
+		}
+
+		var streamed func(string)
+		if onText != nil {
+			streamed = func(text string) { onText(joinContinuation(prefix, trimmed, text)) }
+		}
+
+		resp, err := send(ctx, req, streamed)
+		if err != nil {
+			return "", err
+		}
+
+		if resp.Text == "" && !resp.Truncated {
+			return "", errors.New("empty response")
+		}
+		draft = joinContinuation(prefix, trimmed, resp.Text)
+
+		if !resp.Truncated {
+			return draft, nil
+		}
+
+		if continuation == maxDraftContinuations {
+			return "", truncationError(
+				fmt.Sprintf("the draft (after %d continuations)", maxDraftContinuations),
+				req.Params.MaxTokens,
+				"--draft-max-tokens",
+			)
+		}
+
+		slog.Warn("First draft hit the token limit, continuing",
+			"max_tokens", req.Params.MaxTokens, "continuation", continuation+1)
+		kept := strings.TrimRightFunc(draft, unicode.IsSpace)
+		trimmed = draft[len(kept):]
+		draft = kept
+	}
+}
+
+// joinContinuation appends a continuation to the draft it carries on,
+// putting back the whitespace trimmed from the draft unless the continuation
+// starts with its own. Anthropic continues the prefill, usually with a
+// leading space, while OpenAI answers continuePrompt in a new turn, which
+// rarely has one. A draft that was not trimmed stopped inside or right after
+// a word, and is continued as it is.
+func joinContinuation(draft, trimmed, continuation string) string {
+	first, _ := utf8.DecodeRuneInString(continuation)
+	if trimmed == "" || continuation == "" || unicode.IsSpace(first) {
+		return draft + continuation
+	}
+
+	return draft + trimmed + continuation
+}
+
+// splitTranscript cuts a transcript estimated above maxTokens into parts of
+// similar size, breaking between paragraphs or, failing that, sentences.
+// A maxTokens below zero never splits.
+func splitTranscript(transcript string, maxTokens int) []string {
+	total := estimateTokens(transcript)
+	if maxTokens < 0 || total <= maxTokens {
+		return []string{transcript}
+	}
+
+	// Aim for evenly sized parts rather than full ones and a short tail.
+	// Sizes are counted in characters, matching estimateTokens.
+	count := (total + maxTokens - 1) / maxTokens
+	target := (utf8.RuneCountInString(transcript) + count - 1) / count
+	limit := maxTokens * 4
+
+	var parts []string
+	var part strings.Builder
+	size := 0
+
+	for i, paragraph := range splitParagraphs(transcript) {
+		for j, sentence := range splitSentences(paragraph) {
+			length := utf8.RuneCountInString(sentence) + 1
+			if size > 0 && (size >= target || size+length > limit) {
+				parts = append(parts, part.String())
+				part.Reset()
+				size = 0
+			}
+
+			switch {
+			case part.Len() == 0:
+			case j == 0 && i > 0:
+				part.WriteString("\n\n")
+			default:
+				part.WriteString(" ")
+			}
+
+			part.WriteString(sentence)
+			size += length
+		}
+	}
+
+	if part.Len() > 0 {
+		parts = append(parts, part.String())
+	}
+
+	return parts
+}
+
+// splitParagraphs returns the non-blank paragraphs of text.
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+
+	return paragraphs
+}
+
+// splitSentences cuts text after sentence-ending punctuation followed by
+// whitespace.
+func splitSentences(text string) []string {
+	var sentences []string
+	runes := []rune(text)
+	start := 0
+
+	for i := 0; i < len(runes)-1; i++ {
+		if strings.ContainsRune(".!?", runes[i]) && unicode.IsSpace(runes[i+1]) {
+			sentences = append(sentences, strings.TrimSpace(string(runes[start:i+1])))
+			start = i + 1
+		}
+	}
+
+	if rest := strings.TrimSpace(string(runes[start:])); rest != "" {
+		sentences = append(sentences, rest)
+	}
+
+	return sentences
+}
+
+// draftRequest wraps one part of a split transcript with instructions to
+// draft it as a section of the whole post. A single part is sent as is.
+func draftRequest(part string, index, count int) string {
+	if count == 1 {
+		return part
+	}
+
+	var instructions string
+	switch index {
+	case 0:
+		instructions = "Later parts continue the same post, so do not wrap it up at the end."
+	case count - 1:
+		instructions = "It continues the post drafted from the earlier parts, so do not add a title or introduction."
+	default:
+		instructions = "It continues the post drafted from the earlier parts, so do not add a title or " +
+			"introduction, and later parts follow, so do not wrap it up at the end."
+	}
+
+	return fmt.Sprintf(
+		"This transcript is part %d of %d of one long voice memo. Draft only this part. %s\n\n"+
+			"<transcript>\n%s\n</transcript>",
+		index+1, count, instructions, part,
+	)
+}
@@ -0,0 +1,153 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTranscript_Short(t *testing.T) {
+	transcript := "One sentence. Another one."
+
+	assert.Equal(t, []string{transcript}, splitTranscript(transcript, 100))
+	assert.Equal(t, []string{transcript}, splitTranscript(transcript, -1), "Negative limits never split")
+}
+
+func TestSplitTranscript_Sentences(t *testing.T) {
+	// 30 sentences of 40 characters, about 300 tokens
+	sentence := "This sentence is exactly forty chars ok."
+	transcript := strings.TrimSpace(strings.Repeat(sentence+" ", 30))
+
+	parts := splitTranscript(transcript, 100)
+
+	require.Len(t, parts, 4)
+	for _, part := range parts {
+		assert.LessOrEqual(t, estimateTokens(part), 100)
+		assert.True(t, strings.HasSuffix(part, "ok."), "Parts end at a sentence: %q", part)
+	}
+	assert.Equal(t, transcript, strings.Join(parts, " "), "No text is lost")
+}
+
+func TestSplitTranscript_Paragraphs(t *testing.T) {
+	first := strings.Repeat("a", 200) + "."
+	second := strings.Repeat("b", 200) + "."
+
+	parts := splitTranscript(first+"\n\n"+second, 60)
+
+	assert.Equal(t, []string{first, second}, parts)
+}
+
+func TestDraftRequest(t *testing.T) {
+	assert.Equal(t, "transcript", draftRequest("transcript", 0, 1))
+
+	first := draftRequest("start", 0, 3)
+	assert.Contains(t, first, "part 1 of 3")
+	assert.Contains(t, first, "do not wrap it up")
+	assert.Contains(t, first, "<transcript>\nstart\n</transcript>")
+
+	last := draftRequest("end", 2, 3)
+	assert.Contains(t, last, "part 3 of 3")
+	assert.Contains(t, last, "do not add a title")
+	assert.NotContains(t, last, "wrap it up")
+}
+
+func TestContinueDraft_ContinuesAfterTokenLimit(t *testing.T) {
+	responses := []*ChatResponse{
+		{Text: "## Intro\n\nFirst half ", Truncated: true},
+		{Text: " second half."},
+	}
+	var requests []ChatRequest
+
+	send := func(_ context.Context, req ChatRequest, onText func(string)) (*ChatResponse, error) {
+		requests = append(requests, req)
+		resp := responses[len(requests)-1]
+		onText(resp.Text)
+
+		return resp, nil
+	}
+
+	req := ChatRequest{
+		Messages: []ChatMessage{{Text: "transcript"}},
+		Params:   GenerationParams{MaxTokens: 10},
+	}
+	var streamed string
+	draft, err := continueDraft(context.Background(), req, send, func(text string) { streamed = text })
+
+	require.NoError(t, err)
+	assert.Equal(t, "## Intro\n\nFirst half second half.", draft)
+	assert.Equal(t, draft, streamed, "Streamed text includes the earlier response")
+
+	require.Len(t, requests, 2)
+	assert.Len(t, requests[0].Messages, 1)
+	require.Len(t, requests[1].Messages, 2)
+	assert.Equal(t, ChatMessage{Assistant: true, Text: "## Intro\n\nFirst half"}, requests[1].Messages[1],
+		"The prefill must not end in whitespace")
+}
+
+func TestGenerateFirstDraft_ContinuesOpenAIReply(t *testing.T) {
+	// The first reply stops at the token limit after "the "; OpenAI's reply to
+	// continuePrompt starts a new turn without the space
+	replies := []struct{ text, finish string }{
+		{"I saw the ", "length"},
+		{"quick fox.", "stop"},
+	}
+	var continuation []any
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if calls == 1 {
+			continuation, _ = body["messages"].([]any)
+		}
+		reply := replies[min(calls, len(replies)-1)]
+		calls++
+
+		chunk, err := json.Marshal(map[string]any{
+			"id": "chunk", "object": "chat.completion.chunk", "model": "llama3.1",
+			"choices": []any{map[string]any{
+				"index": 0, "delta": map[string]any{"content": reply.text}, "finish_reason": reply.finish,
+			}},
+		})
+		assert.NoError(t, err)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "data: %s\n\ndata: [DONE]\n\n", chunk)
+	}))
+	defer server.Close()
+
+	writer := NewWriter(NewOpenAICompatibleProvider(server.URL, "", false), WriterConfig{})
+	draft, err := writer.GenerateFirstDraft(context.Background(), "transcript", ModeMemos, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "I saw the quick fox.", draft)
+
+	require.Len(t, continuation, 4)
+	assert.Equal(t, map[string]any{"role": "assistant", "content": "I saw the"}, continuation[2])
+	assert.Equal(t, map[string]any{"role": "user", "content": continuePrompt}, continuation[3])
+}
+
+func TestContinueDraft_GivesUp(t *testing.T) {
+	calls := 0
+	send := func(context.Context, ChatRequest, func(string)) (*ChatResponse, error) {
+		calls++
+		return &ChatResponse{Text: "more", Truncated: true}, nil
+	}
+
+	_, err := continueDraft(context.Background(), ChatRequest{Params: GenerationParams{MaxTokens: 10}}, send, nil)
+
+	require.ErrorIs(t, err, ErrTruncated)
+	assert.ErrorContains(t, err, "--draft-max-tokens")
+	assert.Equal(t, maxDraftContinuations+1, calls)
+}
+
+func TestJoinDrafts(t *testing.T) {
+	assert.Equal(t, "## One\n\nText.\n\n## Two", joinDrafts([]string{"## One\n\nText.\n", "", "\n## Two"}))
+}
@@ -0,0 +1,100 @@
+package content
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultExemplarTokenBudget bounds the style examples added to prompts.
+const DefaultExemplarTokenBudget = 6000
+
+// ExemplarOptions controls which existing posts are used as style examples.
+type ExemplarOptions struct {
+	// Count is the most posts to pick. Zero disables exemplars.
+	Count int
+	// TokenBudget caps the estimated tokens of all picked posts.
+	// Zero uses DefaultExemplarTokenBudget.
+	TokenBudget int
+	// Tags ranks posts sharing more of these tags first. Without tags, or
+	// among posts sharing as many, newer posts come first.
+	Tags []string
+}
+
+// SelectExemplars picks representative posts to show the writer as style
+// examples. Posts that would exceed the token budget are skipped.
+// posts must be sorted newest first, as LoadPosts returns them.
+func SelectExemplars(posts []Post, opts ExemplarOptions) []Post {
+	if opts.Count <= 0 {
+		return nil
+	}
+
+	budget := opts.TokenBudget
+	if budget <= 0 {
+		budget = DefaultExemplarTokenBudget
+	}
+
+	wanted := map[string]bool{}
+	for _, tag := range opts.Tags {
+		wanted[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+
+	ranked := make([]Post, len(posts))
+	copy(ranked, posts)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return sharedTags(ranked[i], wanted) > sharedTags(ranked[j], wanted)
+	})
+
+	var picked []Post
+	for _, post := range ranked {
+		if len(picked) == opts.Count {
+			break
+		}
+
+		tokens := estimateTokens(post.Body)
+		if tokens > budget {
+			continue
+		}
+
+		budget -= tokens
+		picked = append(picked, post)
+	}
+
+	return picked
+}
+
+func sharedTags(post Post, wanted map[string]bool) int {
+	shared := 0
+	for _, tag := range post.Frontmatter.Tags {
+		if wanted[strings.ToLower(strings.TrimSpace(tag))] {
+			shared++
+		}
+	}
+
+	return shared
+}
+
+// estimateTokens approximates the token count of English text at four
+// characters per token.
+func estimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// exemplarPrompt formats posts as style examples for a system prompt.
+// Returns "" without posts.
+func exemplarPrompt(posts []Post) string {
+	if len(posts) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Here are published posts from the same blog. Match their voice, tone, " +
+		"sentence length and structure, but never copy their content.\n")
+
+	for _, post := range posts {
+		fmt.Fprintf(&sb, "\n<example title=%q>\n%s\n</example>\n", post.Frontmatter.Title, strings.TrimSpace(post.Body))
+	}
+
+	return strings.TrimSpace(sb.String())
+}
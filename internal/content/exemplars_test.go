@@ -0,0 +1,67 @@
+package content
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func examplePost(title, date string, words int, tags ...string) Post {
+	return Post{
+		Frontmatter: Frontmatter{Title: title, Date: date, Tags: tags},
+		Body:        strings.Repeat("word ", words),
+	}
+}
+
+// Posts newest first, as LoadPosts returns them
+var examplePosts = []Post{
+	examplePost("Newest", "2026-01-10", 100, "Journal"),
+	examplePost("Go Tips", "2025-12-01", 100, "Go", "CLI Tools"),
+	examplePost("Huge", "2025-11-20", 10000, "Go"),
+	examplePost("Oldest", "2025-10-01", 100, "go"),
+}
+
+func titles(posts []Post) []string {
+	var out []string
+	for _, post := range posts {
+		out = append(out, post.Frontmatter.Title)
+	}
+
+	return out
+}
+
+func TestSelectExemplars_Recency(t *testing.T) {
+	picked := SelectExemplars(examplePosts, ExemplarOptions{Count: 2})
+
+	assert.Equal(t, []string{"Newest", "Go Tips"}, titles(picked))
+}
+
+func TestSelectExemplars_MatchingTags(t *testing.T) {
+	picked := SelectExemplars(examplePosts, ExemplarOptions{Count: 3, Tags: []string{"go", "CLI Tools"}})
+
+	// Huge shares a tag but does not fit the default budget
+	assert.Equal(t, []string{"Go Tips", "Oldest", "Newest"}, titles(picked))
+}
+
+func TestSelectExemplars_TokenBudget(t *testing.T) {
+	// Each small post is 500 characters, about 125 tokens
+	picked := SelectExemplars(examplePosts, ExemplarOptions{Count: 3, TokenBudget: 300})
+
+	assert.Equal(t, []string{"Newest", "Go Tips"}, titles(picked))
+	assert.Empty(t, SelectExemplars(examplePosts, ExemplarOptions{}), "Zero count disables exemplars")
+}
+
+func TestWriter_StyledSystem(t *testing.T) {
+	plain := NewWriter(NewAnthropicProvider("test-api-key"), WriterConfig{}).styledSystem("prompt")
+	assert.Len(t, plain, 1)
+
+	styled := NewWriter(NewAnthropicProvider("test-api-key"), WriterConfig{
+		Exemplars: []Post{{Frontmatter: Frontmatter{Title: "Go Tips"}, Body: "Short sentences.\n"}},
+	}).styledSystem("prompt")
+
+	require.Len(t, styled, 2)
+	assert.Equal(t, "prompt", styled[0])
+	assert.Contains(t, styled[1], "<example title=\"Go Tips\">\nShort sentences.\n</example>")
+}
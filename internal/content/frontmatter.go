@@ -0,0 +1,167 @@
+package content
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const frontmatterDelimiter = "---"
+
+// Frontmatter holds the Hugo frontmatter fields the voice CLI cares about.
+type Frontmatter struct {
+	Title string   `yaml:"title"`
+	Date  string   `yaml:"date"`
+	Tags  []string `yaml:"tags"`
+}
+
+// SplitFrontmatter separates a markdown document into its YAML frontmatter
+// and body. Returns ok=false when the document has no frontmatter block.
+func SplitFrontmatter(markdown string) (string, string, bool) {
+	text := strings.TrimLeft(markdown, "\ufeff\n")
+	if !strings.HasPrefix(text, frontmatterDelimiter+"\n") {
+		return "", markdown, false
+	}
+
+	rest := text[len(frontmatterDelimiter)+1:]
+
+	end := strings.Index(rest, "\n"+frontmatterDelimiter)
+	if end < 0 {
+		return "", markdown, false
+	}
+
+	front := rest[:end]
+	body := rest[end+len(frontmatterDelimiter)+1:]
+	body = strings.TrimLeft(body, "\n")
+
+	return front, body, true
+}
+
+// ParseFrontmatter decodes the YAML frontmatter of a markdown document.
+func ParseFrontmatter(markdown string) (*Frontmatter, string, error) {
+	front, body, ok := SplitFrontmatter(markdown)
+	if !ok {
+		return nil, markdown, errors.New("missing frontmatter block")
+	}
+
+	var fm Frontmatter
+	if err := yaml.Unmarshal([]byte(front), &fm); err != nil {
+		return nil, body, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+
+	return &fm, body, nil
+}
+
+// MissingFrontmatter returns the fields that the markdown's frontmatter
+// lacks, in the order given. Every field is missing when there is no
+// frontmatter block or it cannot be parsed.
+func MissingFrontmatter(markdown string, fields []string) []string {
+	var values map[string]any
+
+	if front, _, ok := SplitFrontmatter(markdown); ok {
+		if err := yaml.Unmarshal([]byte(front), &values); err != nil {
+			values = nil
+		}
+	}
+
+	var missing []string
+	for _, field := range fields {
+		if _, ok := values[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+
+	return missing
+}
+
+// SetFrontmatterTags replaces the tags field of the markdown's frontmatter,
+// adding it when missing. Other fields keep their order and quoting.
+func SetFrontmatterTags(markdown string, tags []string) (string, error) {
+	// Match the flow style the prompts ask for, e.g. ["Go", "CLI Tools"]
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+	for _, tag := range tags {
+		seq.Content = append(seq.Content, quotedNode(tag))
+	}
+
+	return editFrontmatter(markdown, func(mapping *yaml.Node) {
+		setMappingField(mapping, "tags", seq)
+	})
+}
+
+// SetTitle replaces the title field of the markdown's frontmatter. A heading
+// opening the body that repeats oldTitle, as many posts start with, is renamed
+// too.
+func SetTitle(markdown, oldTitle, title string) (string, error) {
+	markdown, err := editFrontmatter(markdown, func(mapping *yaml.Node) {
+		setMappingField(mapping, "title", quotedNode(title))
+	})
+	if err != nil {
+		return "", err
+	}
+
+	front, body, _ := SplitFrontmatter(markdown)
+	line, rest, found := strings.Cut(body, "\n")
+	if oldTitle == "" || strings.TrimSpace(line) != "# "+oldTitle {
+		return markdown, nil
+	}
+
+	body = "# " + title
+	if found {
+		body += "\n" + rest
+	}
+
+	return frontmatterDelimiter + "\n" + front + "\n" + frontmatterDelimiter + "\n\n" + body, nil
+}
+
+// editFrontmatter lets edit change the frontmatter mapping of the markdown
+// and renders the document again.
+func editFrontmatter(markdown string, edit func(mapping *yaml.Node)) (string, error) {
+	front, body, ok := SplitFrontmatter(markdown)
+	if !ok {
+		return "", errors.New("missing frontmatter block")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(front), &doc); err != nil {
+		return "", fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return "", errors.New("frontmatter is not a mapping")
+	}
+	edit(doc.Content[0])
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return "", fmt.Errorf("failed to encode frontmatter: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode frontmatter: %w", err)
+	}
+
+	return frontmatterDelimiter + "\n" + buf.String() + frontmatterDelimiter + "\n\n" + body, nil
+}
+
+// setMappingField replaces the value of key in a YAML mapping, appending the
+// key when missing.
+func setMappingField(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// quotedNode is a double-quoted YAML string, the style the prompts ask for.
+func quotedNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: yaml.DoubleQuotedStyle}
+}
@@ -0,0 +1,125 @@
+package content_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFrontmatter(t *testing.T) {
+	markdown := "---\ntitle: \"Voice CLI Kickoff\"\ndate: 2026-01-15\n" +
+		"tags: [\"Go\", \"CLI Tools\"]\n---\n\n# Voice CLI Kickoff\n\nBody text."
+
+	fm, body, err := content.ParseFrontmatter(markdown)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Voice CLI Kickoff", fm.Title)
+	assert.Equal(t, "2026-01-15", fm.Date)
+	assert.Equal(t, []string{"Go", "CLI Tools"}, fm.Tags)
+	assert.Equal(t, "# Voice CLI Kickoff\n\nBody text.", body)
+}
+
+func TestParseFrontmatter_Missing(t *testing.T) {
+	markdown := "# Just a heading\n\nNo frontmatter here."
+
+	_, body, err := content.ParseFrontmatter(markdown)
+
+	require.Error(t, err)
+	assert.Equal(t, markdown, body)
+}
+
+func TestMissingFrontmatter(t *testing.T) {
+	markdown := "---\ntitle: \"Standup\"\ndate: 2026-10-18\ndraft: false\n---\n\nBody."
+
+	assert.Empty(t, content.MissingFrontmatter(markdown, []string{"title", "date", "draft"}))
+	assert.Equal(t, []string{"tags", "voiceBased"},
+		content.MissingFrontmatter(markdown, []string{"title", "tags", "voiceBased"}))
+	assert.Equal(t, []string{"title"}, content.MissingFrontmatter("No frontmatter", []string{"title"}))
+}
+
+func TestLoadPosts(t *testing.T) {
+	dir := t.TempDir()
+
+	//nolint:gosec // Test files
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2025-10-older.md"),
+		[]byte("---\ntitle: Older\ndate: 2025-10-01\n---\nOld."), 0o644))
+	//nolint:gosec // Test files
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2026-01-newer.md"),
+		[]byte("---\ntitle: Newer\ndate: 2026-01-15\n---\nNew."), 0o644))
+	//nolint:gosec // Test files
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "_index.md"),
+		[]byte("---\ntitle: Posts\n---\n"), 0o644))
+	//nolint:gosec // Test files
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.md"), []byte("no frontmatter"), 0o644))
+
+	posts, err := content.LoadPosts(dir)
+
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, "Newer", posts[0].Frontmatter.Title)
+	assert.Equal(t, "2026-01-newer", posts[0].Slug)
+	assert.Equal(t, "Older", posts[1].Frontmatter.Title)
+}
+
+func TestExistingTags(t *testing.T) {
+	posts := []content.Post{
+		{Frontmatter: content.Frontmatter{Tags: []string{"Go", "CLI Tools"}}},
+		{Frontmatter: content.Frontmatter{Tags: []string{"go", "AI Assisted Dev"}}},
+		{Frontmatter: content.Frontmatter{Tags: []string{"AI Assisted Dev", "Go", " "}}},
+	}
+
+	assert.Equal(t, []string{"Go", "AI Assisted Dev", "CLI Tools"}, content.ExistingTags(posts))
+}
+
+func TestTagIndex_Split(t *testing.T) {
+	index := content.NewTagIndex([]content.Post{
+		{Frontmatter: content.Frontmatter{Tags: []string{"Go", "CLI Tools"}}},
+	}).With("Voice Memos")
+
+	existing, proposed := index.Split([]string{"go", "Rust", "voice memos", "GO", " ", "Rust"})
+
+	assert.Equal(t, []string{"Go", "Voice Memos"}, existing, "Existing tags use the indexed spelling")
+	assert.Equal(t, []string{"Rust"}, proposed)
+
+	var empty *content.TagIndex
+	existing, proposed = empty.Split([]string{"Go"})
+	assert.Empty(t, existing)
+	assert.Equal(t, []string{"Go"}, proposed)
+}
+
+func TestSetFrontmatterTags(t *testing.T) {
+	markdown := "---\ntitle: \"Tags\"\ndate: 2026-10-18\ntags: [\"Go\", \"Rust\"]\ndraft: false\n---\n\n# Tags\n\nBody."
+
+	updated, err := content.SetFrontmatterTags(markdown, []string{"Go", "CLI Tools"})
+
+	require.NoError(t, err)
+	assert.Equal(t,
+		"---\ntitle: \"Tags\"\ndate: 2026-10-18\ntags: [\"Go\", \"CLI Tools\"]\ndraft: false\n---\n\n# Tags\n\nBody.",
+		updated)
+
+	// A missing field is added
+	updated, err = content.SetFrontmatterTags("---\ntitle: Note\n---\n\nBody.", []string{"Go"})
+	require.NoError(t, err)
+	assert.Contains(t, updated, "title: Note\ntags: [\"Go\"]\n---")
+
+	_, err = content.SetFrontmatterTags("No frontmatter", nil)
+	require.Error(t, err)
+}
+
+func TestSetTitle(t *testing.T) {
+	markdown := "---\ntitle: \"Slow Start\"\ndate: 2026-10-18\n---\n\n# Slow Start\n\nBody."
+
+	updated, err := content.SetTitle(markdown, "Slow Start", "Warming Up")
+
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: \"Warming Up\"\ndate: 2026-10-18\n---\n\n# Warming Up\n\nBody.", updated)
+
+	// Other headings are left alone
+	updated, err = content.SetTitle("---\ntitle: Note\n---\n\n# Intro\n\nBody.", "Note", "Memo")
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: \"Memo\"\n---\n\n# Intro\n\nBody.", updated)
+}
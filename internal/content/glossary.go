@@ -0,0 +1,201 @@
+package content
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxGlossaryPromptChars bounds the transcription prompt. Whisper only
+// considers the final 224 tokens of a prompt, so anything longer is wasted.
+const maxGlossaryPromptChars = 800
+
+// minProperNounCount is how many times a capitalized word must appear
+// mid-sentence across posts before it is treated as a proper noun.
+const minProperNounCount = 2
+
+// properNounPattern matches capitalized words, including dotted names
+// like "Fly.io" and camel-cased names like "BubbleTea".
+var properNounPattern = regexp.MustCompile(`[A-Z][A-Za-z0-9]*(?:\.[a-z]{2,})?`)
+
+// Glossary is a list of proper nouns and jargon that transcription should spell correctly.
+type Glossary struct {
+	Terms []string
+}
+
+// ReadGlossary parses a glossary with one term per line.
+// Blank lines and lines starting with # are ignored.
+func ReadGlossary(r io.Reader) (*Glossary, error) {
+	g := &Glossary{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		g.Add(line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read glossary: %w", err)
+	}
+
+	return g, nil
+}
+
+// LoadGlossary reads the glossary file at path.
+// A missing file yields an empty glossary.
+func LoadGlossary(path string) (*Glossary, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Glossary{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open glossary %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return ReadGlossary(file)
+}
+
+// GlossaryFromPosts collects tags and recurring proper nouns from existing posts.
+func GlossaryFromPosts(posts []Post) *Glossary {
+	g := &Glossary{}
+
+	counts := map[string]int{}
+	for _, post := range posts {
+		for _, tag := range post.Frontmatter.Tags {
+			g.Add(tag)
+		}
+		for _, noun := range midSentenceCapitals(post.Body) {
+			counts[noun]++
+		}
+	}
+
+	nouns := make([]string, 0, len(counts))
+	for noun, n := range counts {
+		if n >= minProperNounCount {
+			nouns = append(nouns, noun)
+		}
+	}
+
+	// Most frequent first so the prompt budget goes to the names we use most
+	sort.Slice(nouns, func(i, j int) bool {
+		if counts[nouns[i]] != counts[nouns[j]] {
+			return counts[nouns[i]] > counts[nouns[j]]
+		}
+		return nouns[i] < nouns[j]
+	})
+
+	for _, noun := range nouns {
+		g.Add(noun)
+	}
+
+	return g
+}
+
+// Add appends a term unless an equal term (ignoring case) is already present.
+func (g *Glossary) Add(term string) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return
+	}
+
+	for _, existing := range g.Terms {
+		if strings.EqualFold(existing, term) {
+			return
+		}
+	}
+
+	g.Terms = append(g.Terms, term)
+}
+
+// Merge appends the terms of other, keeping g's terms first.
+func (g *Glossary) Merge(other *Glossary) {
+	if other == nil {
+		return
+	}
+
+	for _, term := range other.Terms {
+		g.Add(term)
+	}
+}
+
+// Prompt renders the glossary as a transcription prompt.
+// Terms are included in order until the prompt budget is exhausted.
+func (g *Glossary) Prompt() string {
+	if g == nil || len(g.Terms) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Glossary:")
+
+	for i, term := range g.Terms {
+		sep := " "
+		if i > 0 {
+			sep = ", "
+		}
+		if sb.Len()+len(sep)+len(term)+1 > maxGlossaryPromptChars {
+			break
+		}
+		sb.WriteString(sep)
+		sb.WriteString(term)
+	}
+	sb.WriteString(".")
+
+	return sb.String()
+}
+
+// midSentenceCapitals returns capitalized words that do not start a sentence.
+// Sentence-initial words are ambiguous, so they are skipped.
+func midSentenceCapitals(body string) []string {
+	var nouns []string
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		// Headings are title-cased and shortcodes are markup, not prose
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "{{") {
+			continue
+		}
+
+		for _, loc := range properNounPattern.FindAllStringIndex(line, -1) {
+			word := line[loc[0]:loc[1]]
+			if len(word) < 3 || isSentenceStart(line[:loc[0]]) {
+				continue
+			}
+			if loc[0] > 0 && isWordChar(line[loc[0]-1]) {
+				continue
+			}
+			nouns = append(nouns, word)
+		}
+	}
+
+	return nouns
+}
+
+// isSentenceStart reports whether the text preceding a word ends a sentence.
+func isSentenceStart(prefix string) bool {
+	prefix = strings.TrimRight(prefix, " \t\"'*_([")
+	if prefix == "" {
+		return true
+	}
+
+	switch prefix[len(prefix)-1] {
+	case '.', '!', '?', ':', '-', '>':
+		return true
+	}
+
+	return false
+}
+
+func isWordChar(b byte) bool {
+	return b == '_' || b == '-' || b == '/' ||
+		('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
@@ -0,0 +1,50 @@
+package content_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGlossary(t *testing.T) {
+	input := "# Team names\nBubbletea\n\nClaude\n  Fly.io  \nclaude\n"
+
+	g, err := content.ReadGlossary(strings.NewReader(input))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bubbletea", "Claude", "Fly.io"}, g.Terms)
+}
+
+func TestGlossaryFromPosts(t *testing.T) {
+	posts := []content.Post{
+		{
+			Frontmatter: content.Frontmatter{Tags: []string{"AI Assisted Dev", "Go"}},
+			Body: "# Heading Words\n\nToday I deployed to Fly.io with Claude.\n" +
+				"Then Claude wrote Bubbletea code. Sometimes it works.",
+		},
+		{
+			Frontmatter: content.Frontmatter{Tags: []string{"Go"}},
+			Body:        "We ship on Fly.io because it is easy.",
+		},
+	}
+
+	g := content.GlossaryFromPosts(posts)
+
+	assert.Equal(t, []string{"AI Assisted Dev", "Go", "Claude", "Fly.io"}, g.Terms)
+}
+
+func TestGlossary_Prompt(t *testing.T) {
+	assert.Empty(t, (&content.Glossary{}).Prompt())
+
+	g := &content.Glossary{Terms: []string{"Bubbletea", "Claude", "Fly.io"}}
+	assert.Equal(t, "Glossary: Bubbletea, Claude, Fly.io.", g.Prompt())
+
+	long := &content.Glossary{}
+	for range 500 {
+		long.Terms = append(long.Terms, "Term")
+	}
+	assert.LessOrEqual(t, len(long.Prompt()), 800)
+}
@@ -0,0 +1,290 @@
+package content
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxRelatedPosts caps the earlier posts offered to the copy editor as link
+// targets.
+const MaxRelatedPosts = 5
+
+// Weights of a draft word found in an earlier post's title, tags or
+// headings when ranking related posts.
+const (
+	titleWordWeight   = 3
+	tagWordWeight     = 2
+	headingWordWeight = 1
+)
+
+var linkWordPattern = regexp.MustCompile(`[a-z0-9]+(?:['.][a-z0-9]+)*`)
+
+// linkStopWords are too common to tie a draft to an earlier post.
+var linkStopWords = map[string]bool{
+	"about": true, "after": true, "again": true, "all": true, "and": true, "are": true, "but": true,
+	"can": true, "for": true, "from": true, "get": true, "getting": true, "had": true, "has": true,
+	"have": true, "how": true, "into": true, "its": true, "just": true, "like": true, "more": true,
+	"not": true, "one": true, "our": true, "out": true, "part": true, "said": true, "the": true,
+	"that": true, "this": true, "was": true, "what": true, "when": true, "why": true, "will": true,
+	"with": true, "you": true, "your": true,
+}
+
+// RelatedPost is an earlier post the copy editor may link to.
+type RelatedPost struct {
+	Title string
+	// Slug is the post's filename without .md, which relref resolves.
+	Slug     string
+	Tags     []string
+	Headings []string
+}
+
+// CrossLink is a suggested link from a phrase of the post to an earlier post.
+type CrossLink struct {
+	// Slug and Title identify the linked post.
+	Slug  string
+	Title string
+	// Text is the phrase of the post that becomes the link.
+	Text string
+}
+
+// PostIndex finds earlier posts a draft refers back to by the words of their
+// titles, tags and headings. A nil index has no posts.
+type PostIndex struct {
+	posts []indexedPost
+}
+
+type indexedPost struct {
+	related RelatedPost
+	// words maps each distinctive word of the post to its weight
+	words map[string]int
+	// phrase is the title as lowercase words separated by single spaces
+	phrase string
+}
+
+// NewPostIndex indexes the posts' titles, slugs, tags and headings. Posts
+// keep their order, newest first from LoadPosts, to break ties.
+func NewPostIndex(posts []Post) *PostIndex {
+	index := &PostIndex{}
+
+	for _, post := range posts {
+		if post.Frontmatter.Title == "" {
+			continue
+		}
+
+		entry := indexedPost{
+			related: RelatedPost{
+				Title:    post.Frontmatter.Title,
+				Slug:     post.Slug,
+				Tags:     post.Frontmatter.Tags,
+				Headings: postHeadings(post.Body, post.Frontmatter.Title),
+			},
+			words: map[string]int{},
+		}
+
+		addWords := func(text string, weight int) {
+			for word := range linkWords(text) {
+				entry.words[word] = max(entry.words[word], weight)
+			}
+		}
+		for _, heading := range entry.related.Headings {
+			addWords(heading, headingWordWeight)
+		}
+		for _, tag := range post.Frontmatter.Tags {
+			addWords(tag, tagWordWeight)
+		}
+		addWords(post.Frontmatter.Title, titleWordWeight)
+		addWords(strings.ReplaceAll(post.Slug, "-", " "), titleWordWeight)
+		entry.phrase = wordPhrase(post.Frontmatter.Title)
+
+		index.posts = append(index.posts, entry)
+	}
+
+	return index
+}
+
+// Related returns up to limit earlier posts the markdown may refer back to,
+// best match first. A post qualifies when the markdown mentions its title or
+// shares at least two distinctive words of it, so a shared tag alone is not
+// enough. The post being edited, found by its frontmatter title, is left out.
+func (pi *PostIndex) Related(markdown string, limit int) []RelatedPost {
+	if pi == nil || limit <= 0 {
+		return nil
+	}
+
+	title := ""
+	if fm, _, err := ParseFrontmatter(markdown); err == nil {
+		title = fm.Title
+	}
+	_, body, _ := SplitFrontmatter(markdown)
+	words := linkWords(body)
+	phrase := " " + wordPhrase(body) + " "
+
+	type match struct {
+		related RelatedPost
+		score   int
+	}
+
+	var matches []match
+	for _, post := range pi.posts {
+		if strings.EqualFold(post.related.Title, title) {
+			continue
+		}
+
+		score, titleMatches := 0, 0
+		for word, weight := range post.words {
+			if !words[word] {
+				continue
+			}
+
+			score += weight
+			if weight == titleWordWeight {
+				titleMatches++
+			}
+		}
+
+		if titleMatches >= 2 || strings.Contains(phrase, " "+post.phrase+" ") {
+			matches = append(matches, match{related: post.related, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	related := make([]RelatedPost, 0, min(limit, len(matches)))
+	for _, m := range matches[:min(limit, len(matches))] {
+		related = append(related, m.related)
+	}
+
+	return related
+}
+
+// linkWords returns the distinctive lowercase words of text.
+func linkWords(text string) map[string]bool {
+	words := map[string]bool{}
+	for _, word := range linkWordPattern.FindAllString(strings.ToLower(text), -1) {
+		if len(word) < 3 || linkStopWords[word] || strings.Trim(word, "0123456789") == "" {
+			continue
+		}
+		words[word] = true
+	}
+
+	return words
+}
+
+// wordPhrase returns the lowercase words of text separated by single spaces.
+func wordPhrase(text string) string {
+	return strings.Join(linkWordPattern.FindAllString(strings.ToLower(text), -1), " ")
+}
+
+// postHeadings returns the body's section headings, leaving out one that
+// repeats the title.
+func postHeadings(body, title string) []string {
+	var headings []string
+	for _, line := range strings.Split(body, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		heading := strings.TrimSpace(strings.TrimLeft(line, "#"))
+		if heading != "" && !strings.EqualFold(heading, title) {
+			headings = append(headings, heading)
+		}
+	}
+
+	return headings
+}
+
+// relatedPostsPrompt lists the related posts after the text sent to the
+// copy editor, which suggests links to them in cross_links.
+func relatedPostsPrompt(related []RelatedPost) string {
+	if len(related) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n\n<related_posts>\n")
+	for _, post := range related {
+		fmt.Fprintf(&sb, "- %q (slug: %s", post.Title, post.Slug)
+		if len(post.Tags) > 0 {
+			fmt.Fprintf(&sb, "; tags: %s", strings.Join(post.Tags, ", "))
+		}
+		if len(post.Headings) > 0 {
+			fmt.Fprintf(&sb, "; sections: %s", strings.Join(post.Headings, ", "))
+		}
+		sb.WriteString(")\n")
+	}
+	sb.WriteString("</related_posts>")
+
+	return sb.String()
+}
+
+// RelrefLink renders a Markdown link to an earlier post through Hugo's relref
+// shortcode, e.g. [kickoff]({{< relref "2025-11-voice-cli-kickoff.md" >}}).
+func RelrefLink(text, slug string) string {
+	return fmt.Sprintf(`[%s]({{< relref "%s.md" >}})`, text, slug)
+}
+
+// InsertCrossLink links the first occurrence of the link's text in the
+// markdown's body. Headings, code, shortcodes and existing links are skipped.
+// Reports false when the text was not found.
+func InsertCrossLink(markdown string, link CrossLink) (string, bool) {
+	if link.Text == "" {
+		return markdown, false
+	}
+
+	front, body, hasFront := SplitFrontmatter(markdown)
+	lines := strings.Split(body, "\n")
+	inFence := false
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "{{") {
+			continue
+		}
+
+		at := linkableIndex(line, link.Text)
+		if at < 0 {
+			continue
+		}
+
+		lines[i] = line[:at] + RelrefLink(link.Text, link.Slug) + line[at+len(link.Text):]
+		body = strings.Join(lines, "\n")
+		if !hasFront {
+			return body, true
+		}
+
+		return frontmatterDelimiter + "\n" + front + "\n" + frontmatterDelimiter + "\n\n" + body, true
+	}
+
+	return markdown, false
+}
+
+// linkableIndex returns where text first appears in line outside links,
+// inline code and shortcodes, or -1.
+func linkableIndex(line, text string) int {
+	for start := 0; start < len(line); {
+		i := strings.Index(line[start:], text)
+		if i < 0 {
+			return -1
+		}
+		at := start + i
+
+		before := line[:at]
+		inLink := strings.Count(before, "[") > strings.Count(before, "]") ||
+			strings.LastIndex(before, "](") > strings.LastIndex(before, ")")
+		inCode := strings.Count(before, "`")%2 == 1
+		inShortcode := strings.Count(before, "{{") > strings.Count(before, "}}")
+		if !inLink && !inCode && !inShortcode {
+			return at
+		}
+
+		start = at + len(text)
+	}
+
+	return -1
+}
@@ -0,0 +1,72 @@
+package content_test
+
+import (
+	"testing"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPostIndex() *content.PostIndex {
+	return content.NewPostIndex([]content.Post{
+		{
+			Slug:        "2025-11-voice-cli-kickoff",
+			Frontmatter: content.Frontmatter{Title: "Voice CLI Kickoff", Tags: []string{"Go", "CLI Tools"}},
+			Body:        "# Voice CLI Kickoff\n\nIntro.\n\n## Recording Audio\n\nText.",
+		},
+		{
+			Slug:        "2025-10-start-with-why",
+			Frontmatter: content.Frontmatter{Title: "Start With Why", Tags: []string{"CLI Tools"}},
+			Body:        "Body.",
+		},
+		{
+			Slug:        "2025-11-pr-comments-as-training-loop",
+			Frontmatter: content.Frontmatter{Title: "PR Comments as a Training Loop"},
+			Body:        "Body.",
+		},
+	})
+}
+
+func TestPostIndex_Related(t *testing.T) {
+	index := testPostIndex()
+
+	related := index.Related("Like I said in the voice CLI kickoff, recording audio is hard.", 5)
+
+	require.Len(t, related, 1)
+	assert.Equal(t, content.RelatedPost{
+		Title:    "Voice CLI Kickoff",
+		Slug:     "2025-11-voice-cli-kickoff",
+		Tags:     []string{"Go", "CLI Tools"},
+		Headings: []string{"Recording Audio"},
+	}, related[0])
+
+	// One shared word of a title or a shared tag is not enough, the whole
+	// title or two of its words are
+	assert.Empty(t, index.Related("I start every CLI tool with tests.", 5))
+	assert.Len(t, index.Related("Always start with why.", 5), 1)
+	assert.Len(t, index.Related("Review comments became my training data.", 5), 1)
+
+	// The post being edited is not related to itself
+	assert.Empty(t, index.Related("---\ntitle: Voice CLI Kickoff\n---\n\nThe voice CLI kickoff.", 5))
+	assert.Empty(t, (*content.PostIndex)(nil).Related("voice CLI kickoff", 5))
+}
+
+func TestInsertCrossLink(t *testing.T) {
+	link := content.CrossLink{Slug: "2025-11-voice-cli-kickoff", Text: "voice CLI kickoff"}
+	markdown := "---\ntitle: \"Next\"\n---\n\n# The voice CLI kickoff\n\n" +
+		"See `voice CLI kickoff` and [the voice CLI kickoff](https://example.com).\n\n" +
+		"As I said in the voice CLI kickoff, it starts small."
+
+	linked, ok := content.InsertCrossLink(markdown, link)
+
+	require.True(t, ok)
+	assert.Equal(t, "---\ntitle: \"Next\"\n---\n\n# The voice CLI kickoff\n\n"+
+		"See `voice CLI kickoff` and [the voice CLI kickoff](https://example.com).\n\n"+
+		"As I said in the [voice CLI kickoff]({{< relref \"2025-11-voice-cli-kickoff.md\" >}}), it starts small.",
+		linked)
+
+	// Linked text is not linked again
+	_, ok = content.InsertCrossLink(linked, link)
+	assert.False(t, ok)
+}
@@ -0,0 +1,69 @@
+package content
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readingWordsPerMinute matches the reading speed Hugo assumes for
+// .ReadingTime, so the frontmatter agrees with the theme.
+const readingWordsPerMinute = 213
+
+// MaxDescriptionLength is the longest description search engines and link
+// previews show without cutting it off.
+const MaxDescriptionLength = 160
+
+var shortcodeBlockPattern = regexp.MustCompile(`\{\{[<%].*?[>%]\}\}`)
+
+// PostMetadata describes a post for search results and link previews.
+type PostMetadata struct {
+	// Description is one or two sentences shown by search engines and as the
+	// OpenGraph description.
+	Description string
+	// ReadingTime is the estimated reading time in minutes.
+	ReadingTime int
+	// SocialBlurb is an optional teaser for sharing the post on social sites.
+	SocialBlurb string
+}
+
+// ReadingTime estimates the minutes needed to read the markdown's body,
+// rounded up the way Hugo does. Frontmatter and shortcodes are not counted.
+func ReadingTime(markdown string) int {
+	_, body, ok := SplitFrontmatter(markdown)
+	if !ok {
+		body = markdown
+	}
+
+	words := len(strings.Fields(shortcodeBlockPattern.ReplaceAllString(body, " ")))
+	if words == 0 {
+		return 0
+	}
+
+	return (words + readingWordsPerMinute - 1) / readingWordsPerMinute
+}
+
+// SetFrontmatterMetadata merges the metadata into the markdown's frontmatter
+// as description, readingTime and socialBlurb. Empty fields leave the
+// frontmatter's value in place.
+func SetFrontmatterMetadata(markdown string, metadata PostMetadata) (string, error) {
+	return editFrontmatter(markdown, func(mapping *yaml.Node) {
+		if metadata.Description != "" {
+			setMappingField(mapping, "description", quotedNode(metadata.Description))
+		}
+
+		if metadata.ReadingTime > 0 {
+			setMappingField(mapping, "readingTime", &yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Tag:   "!!int",
+				Value: strconv.Itoa(metadata.ReadingTime),
+			})
+		}
+
+		if metadata.SocialBlurb != "" {
+			setMappingField(mapping, "socialBlurb", quotedNode(metadata.SocialBlurb))
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package content_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadingTime(t *testing.T) {
+	words := strings.Repeat("word ", 214)
+
+	assert.Equal(t, 2, content.ReadingTime("---\ntitle: \"Post\"\n---\n\n"+words+"{{< byline >}}"),
+		"Rounds up like Hugo")
+	assert.Equal(t, 1, content.ReadingTime("Short note without frontmatter."))
+	assert.Equal(t, 0, content.ReadingTime("---\ntitle: Empty\n---\n\n{{< byline >}}\n"))
+}
+
+func TestSetFrontmatterMetadata(t *testing.T) {
+	markdown := "---\ntitle: \"Post\"\ndescription: \"Old\"\ndraft: false\n---\n\nBody."
+
+	updated, err := content.SetFrontmatterMetadata(markdown, content.PostMetadata{
+		Description: "What the post is about.",
+		ReadingTime: 4,
+		SocialBlurb: "Read this: it's good.",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: \"Post\"\ndescription: \"What the post is about.\"\ndraft: false\n"+
+		"readingTime: 4\nsocialBlurb: \"Read this: it's good.\"\n---\n\nBody.", updated)
+
+	// Empty fields keep the existing values
+	updated, err = content.SetFrontmatterMetadata(markdown, content.PostMetadata{})
+	require.NoError(t, err)
+	assert.Contains(t, updated, "description: \"Old\"")
+	assert.NotContains(t, updated, "readingTime")
+}
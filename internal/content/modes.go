@@ -0,0 +1,259 @@
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Mode names a content mode, e.g. "memos" or "journal".
+type Mode string
+
+const (
+	// ModeMemos is the default mode with full frontmatter (tags, voiceBased, etc.).
+	ModeMemos Mode = "memos"
+	// ModeJournal is a minimal mode for personal journal entries.
+	ModeJournal Mode = "journal"
+)
+
+// Optional workflow phases a mode can run. Recording and transcription
+// always run.
+const (
+	PhaseViewTranscript = "view-transcript"
+	PhaseFirstDraft     = "first-draft"
+	PhaseEditDraft      = "edit-draft"
+	PhaseCopyEdit       = "copy-edit"
+)
+
+// DefaultFilenamePattern names posts {YYYY-MM}-{slug}.md.
+const DefaultFilenamePattern = `{{.Date.Format "2006-01"}}-{{.Slug}}.md`
+
+var allPhases = []string{PhaseViewTranscript, PhaseFirstDraft, PhaseEditDraft, PhaseCopyEdit}
+
+// ModeConfig describes how a mode drafts and saves posts. Zero fields are
+// filled in by WithDefaults.
+type ModeConfig struct {
+	Name        Mode   `json:"-"`
+	Description string `json:"description,omitempty"`
+
+	// FirstDraftPrompt and CopyEditPrompt name prompt templates.
+	// Default: "first-draft-<mode>" and "copy-edit-<mode>".
+	FirstDraftPrompt string `json:"first_draft_prompt,omitempty"`
+	CopyEditPrompt   string `json:"copy_edit_prompt,omitempty"`
+
+	// Frontmatter lists the fields a copy-edited post must have.
+	Frontmatter []string `json:"frontmatter,omitempty"`
+	// Shortcodes lists Hugo shortcodes a copy-edited post must contain,
+	// e.g. "byline".
+	Shortcodes []string `json:"shortcodes,omitempty"`
+	// OutputDir is where copy-edited posts are saved. Default: ".".
+	OutputDir string `json:"output_dir,omitempty"`
+	// Filename is a text/template for the post file name, executed with
+	// FilenameData. Default: DefaultFilenamePattern.
+	Filename string `json:"filename,omitempty"`
+	// Phases lists the optional phases to run, in workflow order. Default: all.
+	Phases []string `json:"phases,omitempty"`
+	// Redact replaces emails, phone numbers, street addresses and listed
+	// names with placeholders before text is sent to the LLM.
+	Redact bool `json:"redact,omitempty"`
+}
+
+// FilenameData holds the variables available to filename patterns.
+type FilenameData struct {
+	Title string
+	Slug  string
+	Date  time.Time
+	Mode  Mode
+}
+
+// WithDefaults returns the mode with default values applied to zero fields.
+func (m ModeConfig) WithDefaults() ModeConfig {
+	if m.FirstDraftPrompt == "" {
+		m.FirstDraftPrompt = "first-draft-" + string(m.Name)
+	}
+
+	if m.CopyEditPrompt == "" {
+		m.CopyEditPrompt = "copy-edit-" + string(m.Name)
+	}
+
+	if m.Frontmatter == nil {
+		m.Frontmatter = []string{"title", "date"}
+	}
+
+	if m.OutputDir == "" {
+		m.OutputDir = "."
+	}
+
+	if m.Filename == "" {
+		m.Filename = DefaultFilenamePattern
+	}
+
+	if m.Phases == nil {
+		m.Phases = allPhases
+	}
+
+	return m
+}
+
+// Runs reports whether the mode runs the named optional phase.
+func (m ModeConfig) Runs(phase string) bool {
+	return slices.Contains(m.Phases, phase)
+}
+
+// OutputFilename renders the file name for a post with the given title.
+func (m ModeConfig) OutputFilename(title string, date time.Time) (string, error) {
+	tmpl, err := m.filenameTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, FilenameData{
+		Title: title,
+		Slug:  GenerateSlug(title),
+		Date:  date,
+		Mode:  m.Name,
+	}); err != nil {
+		return "", fmt.Errorf("failed to render filename for mode %s: %w", m.Name, err)
+	}
+
+	return buf.String(), nil
+}
+
+func (m ModeConfig) filenameTemplate() (*template.Template, error) {
+	tmpl, err := template.New(string(m.Name)).Option("missingkey=error").Parse(m.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filename pattern for mode %s: %w", m.Name, err)
+	}
+
+	return tmpl, nil
+}
+
+// Validate checks that the mode's phases are known and consistent, its
+// prompts exist and its filename pattern parses.
+func (m ModeConfig) Validate(prompts *Prompts) error {
+	for _, phase := range m.Phases {
+		if !slices.Contains(allPhases, phase) {
+			return fmt.Errorf("mode %s: unknown phase %q: expected one of %s",
+				m.Name, phase, strings.Join(allPhases, ", "))
+		}
+	}
+
+	if !m.Runs(PhaseFirstDraft) && (m.Runs(PhaseEditDraft) || m.Runs(PhaseCopyEdit)) {
+		return fmt.Errorf("mode %s: %s and %s need the %s phase",
+			m.Name, PhaseEditDraft, PhaseCopyEdit, PhaseFirstDraft)
+	}
+
+	if m.Runs(PhaseFirstDraft) && !prompts.Has(m.FirstDraftPrompt) {
+		return fmt.Errorf("mode %s: prompt template %s%s not found", m.Name, m.FirstDraftPrompt, promptExt)
+	}
+
+	if m.Runs(PhaseCopyEdit) && !prompts.Has(m.CopyEditPrompt) {
+		return fmt.Errorf("mode %s: prompt template %s%s not found", m.Name, m.CopyEditPrompt, promptExt)
+	}
+
+	_, err := m.filenameTemplate()
+
+	return err
+}
+
+// Modes is a registry of content modes by name.
+type Modes map[Mode]ModeConfig
+
+// DefaultModes returns the built-in memos and journal modes.
+func DefaultModes() Modes {
+	return Modes{
+		ModeMemos: ModeConfig{
+			Name:        ModeMemos,
+			Description: "Blog post with full frontmatter",
+			Frontmatter: []string{"title", "date", "tags", "voiceBased", "pinned", "draft"},
+			Shortcodes:  []string{"byline"},
+			OutputDir:   "content/posts",
+		}.WithDefaults(),
+		ModeJournal: ModeConfig{
+			Name:        ModeJournal,
+			Description: "Personal journal entry with minimal frontmatter",
+			Frontmatter: []string{"title", "date", "draft"},
+			Shortcodes:  []string{"byline"},
+			Redact:      true,
+		}.WithDefaults(),
+	}
+}
+
+// LoadModes returns the built-in modes plus those under the "modes" key of
+// the JSON config file at path. A configured mode replaces a built-in of the
+// same name. A missing file yields the built-ins.
+func LoadModes(path string) (Modes, error) {
+	modes := DefaultModes()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return modes, nil
+		}
+
+		return nil, fmt.Errorf("failed to read modes from %s: %w", path, err)
+	}
+
+	var config struct {
+		Modes map[Mode]ModeConfig `json:"modes"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse modes from %s: %w", path, err)
+	}
+
+	for name, mode := range config.Modes {
+		mode.Name = name
+		modes[name] = mode.WithDefaults()
+	}
+
+	return modes, nil
+}
+
+// CheckPrompts rejects prompt templates that are neither built in nor named
+// by one of the modes, such as a misspelled override that would never be
+// used.
+func (ms Modes) CheckPrompts(prompts *Prompts) error {
+	known := PromptNames()
+	for _, mode := range ms {
+		known = append(known, mode.FirstDraftPrompt, mode.CopyEditPrompt)
+	}
+
+	for name := range prompts.templates {
+		if !slices.Contains(known, name) {
+			return fmt.Errorf("unknown prompt template %s%s: expected one of %s or a prompt named by a mode",
+				name, promptExt, strings.Join(PromptNames(), ", "))
+		}
+	}
+
+	return nil
+}
+
+// Lookup returns the named mode.
+func (ms Modes) Lookup(name Mode) (ModeConfig, error) {
+	mode, ok := ms[name]
+	if !ok {
+		return ModeConfig{}, fmt.Errorf("invalid mode %q: must be one of %s", name, strings.Join(ms.Names(), ", "))
+	}
+
+	return mode, nil
+}
+
+// Names returns the mode names in alphabetical order.
+func (ms Modes) Names() []string {
+	names := make([]string, 0, len(ms))
+	for name := range ms {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	return names
+}
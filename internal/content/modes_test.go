@@ -0,0 +1,129 @@
+package content_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultModes(t *testing.T) {
+	modes := content.DefaultModes()
+	prompts := content.DefaultPrompts()
+
+	assert.Equal(t, []string{"journal", "memos"}, modes.Names())
+	for _, mode := range modes {
+		require.NoError(t, mode.Validate(prompts), string(mode.Name))
+	}
+
+	memos, err := modes.Lookup(content.ModeMemos)
+	require.NoError(t, err)
+	assert.Equal(t, "content/posts", memos.OutputDir)
+	assert.Equal(t, content.PromptCopyEditMemos, memos.CopyEditPrompt)
+	assert.True(t, memos.Runs(content.PhaseEditDraft))
+
+	journal, err := modes.Lookup(content.ModeJournal)
+	require.NoError(t, err)
+	assert.Equal(t, ".", journal.OutputDir)
+	assert.Equal(t, content.PromptFirstDraftJournal, journal.FirstDraftPrompt)
+
+	_, err = modes.Lookup("standup")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be one of journal, memos")
+}
+
+func TestLoadModes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"draft_model": "claude-haiku-4-5",
+		"modes": {
+			"standup": {
+				"output_dir": "notes/standups",
+				"filename": "{{.Date.Format \"2006-01-02\"}}-standup.md",
+				"phases": ["first-draft", "copy-edit"]
+			},
+			"journal": {"output_dir": "journal"}
+		}
+	}`), 0o644))
+
+	modes, err := content.LoadModes(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"journal", "memos", "standup"}, modes.Names())
+
+	standup, err := modes.Lookup("standup")
+	require.NoError(t, err)
+	assert.Equal(t, "copy-edit-standup", standup.CopyEditPrompt)
+	assert.Equal(t, []string{"title", "date"}, standup.Frontmatter)
+	assert.False(t, standup.Runs(content.PhaseEditDraft))
+
+	filename, err := standup.OutputFilename("Monday", time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-19-standup.md", filename)
+
+	// Prompts for custom modes must exist
+	err = standup.Validate(content.DefaultPrompts())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "first-draft-standup.tmpl not found")
+
+	// Configured modes replace built-ins of the same name
+	journal, err := modes.Lookup(content.ModeJournal)
+	require.NoError(t, err)
+	assert.Equal(t, "journal", journal.OutputDir)
+}
+
+func TestLoadModes_MissingFile(t *testing.T) {
+	modes, err := content.LoadModes(filepath.Join(t.TempDir(), "config.json"))
+
+	require.NoError(t, err)
+	assert.Len(t, modes, 2)
+}
+
+func TestModeConfig_Validate(t *testing.T) {
+	prompts := content.DefaultPrompts()
+	memos := content.DefaultModes()[content.ModeMemos]
+
+	unknown := memos
+	unknown.Phases = []string{"first-draft", "publish"}
+	err := unknown.Validate(prompts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown phase "publish"`)
+
+	noDraft := memos
+	noDraft.Phases = []string{"copy-edit"}
+	require.Error(t, noDraft.Validate(prompts))
+
+	badName := memos
+	badName.Filename = "{{.Slug"
+	require.Error(t, badName.Validate(prompts))
+}
+
+func TestModes_CheckPrompts(t *testing.T) {
+	dir := t.TempDir()
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "copy-edit-standup.tmpl"), []byte("Standup"), 0o644))
+	prompts, err := content.LoadPrompts(dir)
+	require.NoError(t, err)
+
+	modes := content.DefaultModes()
+	err = modes.CheckPrompts(prompts)
+	require.Error(t, err, "No mode uses copy-edit-standup")
+	assert.Contains(t, err.Error(), "unknown prompt template copy-edit-standup.tmpl")
+
+	modes["standup"] = content.ModeConfig{Name: "standup"}.WithDefaults()
+	require.NoError(t, modes.CheckPrompts(prompts))
+	require.NoError(t, content.DefaultModes().CheckPrompts(content.DefaultPrompts()))
+}
+
+func TestModeConfig_OutputFilename(t *testing.T) {
+	memos := content.DefaultModes()[content.ModeMemos]
+
+	filename, err := memos.OutputFilename("Voice CLI Kickoff", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, "2026-01-voice-cli-kickoff.md", filename)
+}
@@ -0,0 +1,394 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// offlineScript is what OfflineTranscriber "hears", filler words and all.
+var offlineScript = []string{
+	"So, um, I've been building a little voice CLI for the blog.",
+	"The idea is, you know, I talk into my laptop and it turns that into a post.",
+	"Uh, recording and transcription run in the terminal, and there's a live preview.",
+	"Then a first draft gets cleaned up and, um, I edit it by hand before the copy edit.",
+	"I mean, it's not perfect, but it gets me from rambling to writing way faster.",
+	"Next up, uh, I want better tags and links between posts.",
+}
+
+// offlineParagraphSentences is how many sentences OfflineWriter puts in a
+// paragraph.
+const offlineParagraphSentences = 3
+
+var (
+	fillerPattern = regexp.MustCompile(
+		`(?i),?\s*\b(?:um+|uh+|erm|you know|I mean)\b,?`)
+	spaceBeforePunctuation = regexp.MustCompile(`\s+([,.!?;:])`)
+	repeatedSpace          = regexp.MustCompile(`[ \t]{2,}`)
+	sentencePattern        = regexp.MustCompile(`[^.!?]+[.!?]+["')\]]*`)
+	headingPattern         = regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
+)
+
+// OfflineTranscriber stands in for Whisper when running without network
+// access, e.g. for demos and end-to-end tests. It hears a fixed script: the
+// whole recording, or its tail after the live preview, is the rest of the
+// script, and each live preview segment passed to its Segments transcriber
+// is the next sentence. It is safe for concurrent use.
+type OfflineTranscriber struct {
+	script *offlineScriptState
+	// segment makes each audio file the next sentence
+	segment bool
+}
+
+// offlineScriptState is how far an OfflineTranscriber and its Segments
+// transcriber got through the script.
+type offlineScriptState struct {
+	mu    sync.Mutex
+	heard int
+}
+
+// NewOfflineTranscriber creates an OfflineTranscriber at the start of its
+// script.
+func NewOfflineTranscriber() *OfflineTranscriber {
+	return &OfflineTranscriber{script: &offlineScriptState{}}
+}
+
+// Segments returns the transcriber for the live preview's segments, sharing
+// t's script.
+func (t *OfflineTranscriber) Segments() *OfflineTranscriber {
+	return &OfflineTranscriber{script: t.script, segment: true}
+}
+
+// TranscribeFile returns the next part of the script.
+func (t *OfflineTranscriber) TranscribeFile(ctx context.Context, _ io.Reader) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("offline transcription cancelled: %w", err)
+	}
+
+	t.script.mu.Lock()
+	defer t.script.mu.Unlock()
+
+	heard := t.script.heard
+	if heard >= len(offlineScript) {
+		return "", nil
+	}
+
+	if t.segment {
+		t.script.heard++
+		return offlineScript[heard], nil
+	}
+
+	t.script.heard = len(offlineScript)
+
+	return strings.Join(offlineScript[heard:], " "), nil
+}
+
+// OfflineWriter stands in for the LLM when running without network access.
+// Its output depends only on its input: first drafts are the transcript
+// without filler words, copy edits add generated frontmatter, a title
+// heading and the mode's shortcodes, and revisions leave the post as it is.
+type OfflineWriter struct {
+	config WriterConfig
+}
+
+// NewOfflineWriter creates an OfflineWriter using the modes, author and
+// required tags of config.
+func NewOfflineWriter(config WriterConfig) *OfflineWriter {
+	if config.Modes == nil {
+		config.Modes = DefaultModes()
+	}
+
+	return &OfflineWriter{config: config}
+}
+
+// GenerateFirstDraft removes filler words from the transcript and splits it
+// into paragraphs. onText, when non-nil, receives the draft paragraph by
+// paragraph.
+func (w *OfflineWriter) GenerateFirstDraft(
+	ctx context.Context,
+	transcript string,
+	_ Mode,
+	onText func(draft string),
+) (string, error) {
+	sentences := offlineSentences(RemoveFillerWords(transcript))
+
+	var paragraphs []string
+	for start := 0; start < len(sentences); start += offlineParagraphSentences {
+		if err := ctx.Err(); err != nil {
+			return "", fmt.Errorf("offline first draft cancelled: %w", err)
+		}
+
+		end := min(start+offlineParagraphSentences, len(sentences))
+		paragraphs = append(paragraphs, strings.Join(sentences[start:end], " "))
+		if onText != nil {
+			onText(strings.Join(paragraphs, "\n\n"))
+		}
+	}
+
+	return strings.Join(paragraphs, "\n\n"), nil
+}
+
+// GenerateCopyEdit adds frontmatter with the mode's fields, a title heading
+// taken from the draft and the mode's shortcodes.
+func (w *OfflineWriter) GenerateCopyEdit(
+	ctx context.Context,
+	firstDraft, currentDate string,
+	mode Mode,
+	_ *TagIndex,
+) (*CopyEditResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("offline copy edit cancelled: %w", err)
+	}
+
+	modeConfig, err := w.config.Modes.Lookup(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	_, body, _ := SplitFrontmatter(firstDraft)
+	body = strings.TrimSpace(body)
+	title := offlineTitle(body)
+	changes := []string{"Generated frontmatter (offline mode)"}
+
+	if !strings.HasPrefix(body, "# ") {
+		body = "# " + title + "\n\n" + body
+		changes = append(changes, "Added a title heading")
+	}
+
+	for _, shortcode := range modeConfig.Shortcodes {
+		if !hasShortcode(body, shortcode) {
+			body += "\n\n{{< " + shortcode + " >}}"
+			changes = append(changes, fmt.Sprintf("Added the %s shortcode", shortcode))
+		}
+	}
+
+	markdown, err := w.frontmatter(body+"\n", title, currentDate, modeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CopyEditResult{
+		Title:           title,
+		TitleCandidates: []string{title},
+		Markdown:        markdown,
+		Changes:         changes,
+		ExistingTags:    w.config.PromptData.Tags,
+		Metadata: PostMetadata{
+			Description: offlineDescription(body),
+			ReadingTime: ReadingTime(markdown),
+		},
+	}
+
+	if result.Markdown, err = SetFrontmatterMetadata(result.Markdown, result.Metadata); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// frontmatter puts frontmatter with the mode's fields in front of body.
+func (w *OfflineWriter) frontmatter(body, title, date string, mode ModeConfig) (string, error) {
+	boolNode := func(value bool) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(value)}
+	}
+
+	markdown, err := editFrontmatter(frontmatterDelimiter+"\n{}\n"+frontmatterDelimiter+"\n\n"+body,
+		func(mapping *yaml.Node) {
+			mapping.Style = 0
+			for _, field := range mode.Frontmatter {
+				switch field {
+				case "title":
+					setMappingField(mapping, field, quotedNode(title))
+				case "date":
+					setMappingField(mapping, field, quotedNode(date))
+				case "author":
+					setMappingField(mapping, field, quotedNode(w.config.PromptData.Author))
+				case "draft", "voiceBased":
+					setMappingField(mapping, field, boolNode(true))
+				case "pinned":
+					setMappingField(mapping, field, boolNode(false))
+				case "tags":
+					// Set below, in the prompts' flow style
+				default:
+					setMappingField(mapping, field, quotedNode(""))
+				}
+			}
+		})
+	if err != nil {
+		return "", err
+	}
+
+	if !slices.Contains(mode.Frontmatter, "tags") {
+		return markdown, nil
+	}
+
+	tags := w.config.PromptData.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return SetFrontmatterTags(markdown, tags)
+}
+
+// ReviseCopyEdit cannot apply feedback offline, so the post is returned as
+// it is.
+func (w *OfflineWriter) ReviseCopyEdit(
+	ctx context.Context,
+	markdown, feedback string,
+	_ Mode,
+	_ *TagIndex,
+) (*CopyEditResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("offline revision cancelled: %w", err)
+	}
+
+	if strings.TrimSpace(feedback) == "" {
+		return nil, errors.New("revision feedback is empty")
+	}
+
+	fm, _, err := ParseFrontmatter(markdown)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CopyEditResult{
+		Title:           fm.Title,
+		TitleCandidates: []string{fm.Title},
+		Markdown:        markdown,
+		Changes:         []string{fmt.Sprintf("Offline mode kept the post as it was; not applied: %q", feedback)},
+		ExistingTags:    fm.Tags,
+	}, nil
+}
+
+// TranslateTranscript returns the transcript unchanged, as English.
+func (w *OfflineWriter) TranslateTranscript(ctx context.Context, transcript string) (*TranslationResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("offline translation cancelled: %w", err)
+	}
+
+	return &TranslationResult{SourceLanguage: "English", Text: transcript}, nil
+}
+
+// GenerateSocialPosts shares the post's title and description, cut to each
+// platform's length limit.
+func (w *OfflineWriter) GenerateSocialPosts(
+	ctx context.Context,
+	markdown string,
+	platforms []SocialPlatform,
+) ([]SocialPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("offline social posts cancelled: %w", err)
+	}
+
+	_, body, _ := SplitFrontmatter(markdown)
+	text := offlineTitle(body) + ": " + offlineDescription(body)
+
+	posts := make([]SocialPost, 0, len(platforms))
+	for _, platform := range platforms {
+		posts = append(posts, SocialPost{Platform: platform, Text: truncateRunes(text, platform.MaxLength)})
+	}
+
+	return posts, nil
+}
+
+// RemoveFillerWords drops verbal tics like "um", "uh" and "you know" from a
+// transcript and tidies the spacing and capitalization they leave behind.
+func RemoveFillerWords(transcript string) string {
+	text := fillerPattern.ReplaceAllString(transcript, " ")
+	text = spaceBeforePunctuation.ReplaceAllString(text, "$1")
+	text = repeatedSpace.ReplaceAllString(text, " ")
+
+	sentences := offlineSentences(text)
+	for i, sentence := range sentences {
+		sentence = strings.TrimLeft(sentence, ", ")
+		runes := []rune(sentence)
+		if len(runes) > 0 {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		sentences[i] = string(runes)
+	}
+
+	return strings.Join(sentences, " ")
+}
+
+// offlineSentences splits text into sentences. Trailing text without end
+// punctuation counts as a sentence.
+func offlineSentences(text string) []string {
+	var sentences []string
+	end := 0
+	for _, loc := range sentencePattern.FindAllStringIndex(text, -1) {
+		if sentence := strings.TrimSpace(text[loc[0]:loc[1]]); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		end = loc[1]
+	}
+
+	if rest := strings.TrimSpace(text[end:]); rest != "" {
+		sentences = append(sentences, rest)
+	}
+
+	return sentences
+}
+
+// offlineTitle is the body's first heading or else the first few words of
+// its first sentence, capitalized.
+func offlineTitle(body string) string {
+	if match := headingPattern.FindStringSubmatch(body); match != nil {
+		return strings.TrimSpace(match[1])
+	}
+
+	sentences := offlineSentences(body)
+	if len(sentences) == 0 {
+		return "Voice Memo"
+	}
+
+	words := strings.Fields(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) || r == '\'' {
+			return r
+		}
+		return ' '
+	}, sentences[0]))
+	words = words[:min(len(words), 6)]
+
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// offlineDescription is the first sentence of the body's text, cut to
+// MaxDescriptionLength.
+func offlineDescription(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "{{") {
+			continue
+		}
+
+		return truncateRunes(offlineSentences(line)[0], MaxDescriptionLength)
+	}
+
+	return ""
+}
+
+// truncateRunes cuts text to at most limit characters, ending with an
+// ellipsis when cut.
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
@@ -0,0 +1,88 @@
+package content_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveFillerWords(t *testing.T) {
+	assert.Equal(t,
+		"So I built it. The idea is I talk and it writes. It's not perfect.",
+		content.RemoveFillerWords(
+			"So, um, I built it. The idea is, you know, I talk and it writes. I mean, it's not perfect."))
+	assert.Equal(t, "Uhm is not a word we drop. Summer is fine.",
+		content.RemoveFillerWords("Uhm is not a word we drop. Uh, summer is fine."))
+}
+
+func TestOfflineTranscriber(t *testing.T) {
+	ctx := context.Background()
+	transcriber := content.NewOfflineTranscriber()
+
+	// Live preview segments hear one sentence each, the tail the rest
+	first, err := transcriber.Segments().TranscribeFile(ctx, strings.NewReader("RIFF....WAVE"))
+	require.NoError(t, err)
+	assert.Equal(t, "So, um, I've been building a little voice CLI for the blog.", first)
+
+	rest, err := transcriber.TranscribeFile(ctx, strings.NewReader("RIFF....WAVE"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rest, "The idea is, you know,"), rest)
+	assert.True(t, strings.HasSuffix(rest, "links between posts."), rest)
+
+	// The whole recording, without a preview, is the whole script
+	whole, err := content.NewOfflineTranscriber().TranscribeFile(ctx, strings.NewReader("ID3..."))
+	require.NoError(t, err)
+	assert.Equal(t, first+" "+rest, whole)
+}
+
+func TestOfflineWriter(t *testing.T) {
+	ctx := context.Background()
+	writer := content.NewOfflineWriter(content.WriterConfig{
+		PromptData: content.PromptData{Author: "James", Tags: []string{"Voice"}},
+	})
+
+	transcript, err := content.NewOfflineTranscriber().TranscribeFile(ctx, strings.NewReader(""))
+	require.NoError(t, err)
+
+	var streamed []string
+	draft, err := writer.GenerateFirstDraft(ctx, transcript, content.ModeMemos, func(text string) {
+		streamed = append(streamed, text)
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, draft, "um,")
+	assert.Equal(t, 2, strings.Count(draft, "\n\n")+1, "six sentences make two paragraphs")
+	assert.Equal(t, []string{draft[:strings.Index(draft, "\n\n")], draft}, streamed)
+
+	for _, mode := range []content.Mode{content.ModeMemos, content.ModeJournal} {
+		result, err := writer.GenerateCopyEdit(ctx, draft, "2026-10-18", mode, nil)
+		require.NoError(t, err)
+
+		// Copy edits pass the checks a real one must
+		assert.Empty(t, content.ValidateCopyEdit(draft, result.Markdown, content.DefaultModes()[mode]), mode)
+		assert.Equal(t, "So I've Been Building A Little", result.Title)
+		assert.Contains(t, result.Markdown, "# So I've Been Building A Little\n\nSo I've been building")
+		assert.Contains(t, result.Markdown, "{{< byline >}}")
+	}
+
+	result, err := writer.GenerateCopyEdit(ctx, draft, "2026-10-18", content.ModeMemos, nil)
+	require.NoError(t, err)
+	fm, _, err := content.ParseFrontmatter(result.Markdown)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-18", fm.Date)
+	assert.Equal(t, []string{"Voice"}, fm.Tags)
+
+	revised, err := writer.ReviseCopyEdit(ctx, result.Markdown, "shorter", content.ModeMemos, nil)
+	require.NoError(t, err)
+	assert.Equal(t, result.Markdown, revised.Markdown)
+	assert.Equal(t, result.Title, revised.Title)
+
+	posts, err := writer.GenerateSocialPosts(ctx, result.Markdown, content.DefaultSocialPlatforms())
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, "So I've Been Building A Little: So I've been building a little voice CLI for the blog.",
+		posts[1].Text)
+}
@@ -0,0 +1,286 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go/shared"
+)
+
+const (
+	// DefaultOpenAIModel is the OpenAI model used by phases without a
+	// configured model.
+	DefaultOpenAIModel = string(openai.ChatModelGPT4_1)
+	// DefaultCompatibleBaseURL is the OpenAI-compatible endpoint of a local
+	// Ollama server.
+	DefaultCompatibleBaseURL = "http://localhost:11434/v1"
+	// DefaultCompatibleModel is the model used with an OpenAI-compatible
+	// endpoint when none is configured.
+	DefaultCompatibleModel = "llama3.1"
+)
+
+// continuePrompt asks for the rest of a reply cut off at the token limit.
+// Chat completions cannot prefill the assistant's reply, so the partial reply
+// is sent as an earlier turn instead.
+const continuePrompt = "Continue your reply exactly where it stopped. Do not repeat any of it."
+
+// OpenAIProvider sends requests to the OpenAI Chat Completions API or an
+// OpenAI-compatible endpoint such as Ollama.
+type OpenAIProvider struct {
+	apiKey  string
+	baseURL string
+	// compatible marks an endpoint other than OpenAI's, which may lack newer
+	// parameters such as max_completion_tokens.
+	compatible bool
+	// jsonMode requests structured output as a JSON object instead of a
+	// function call.
+	jsonMode bool
+}
+
+// NewOpenAIProvider creates a provider for the OpenAI Chat Completions API.
+func NewOpenAIProvider(apiKey string) *OpenAIProvider {
+	return &OpenAIProvider{apiKey: apiKey}
+}
+
+// NewOpenAICompatibleProvider creates a provider for an OpenAI-compatible
+// endpoint. An empty baseURL uses DefaultCompatibleBaseURL; apiKey may be
+// empty for local servers. jsonMode requests structured output as a JSON
+// object, for models without function calling.
+func NewOpenAICompatibleProvider(baseURL, apiKey string, jsonMode bool) *OpenAIProvider {
+	if baseURL == "" {
+		baseURL = DefaultCompatibleBaseURL
+	}
+
+	return &OpenAIProvider{apiKey: apiKey, baseURL: baseURL, compatible: true, jsonMode: jsonMode}
+}
+
+// Name implements Provider.
+func (p *OpenAIProvider) Name() string {
+	if p.compatible {
+		return "OpenAI-compatible API at " + p.baseURL
+	}
+
+	return "OpenAI API"
+}
+
+// DefaultModel implements Provider.
+func (p *OpenAIProvider) DefaultModel() string {
+	if p.compatible {
+		return DefaultCompatibleModel
+	}
+
+	return DefaultOpenAIModel
+}
+
+// Validate implements Provider.
+func (p *OpenAIProvider) Validate() error {
+	if !p.compatible && p.apiKey == "" {
+		return errors.New("API key required: set OPENAI_API_KEY or use --openai-api-key")
+	}
+
+	return nil
+}
+
+// newClient creates an OpenAI client. SDK retries are disabled because
+// requests go through the writer's own retry policy. The key is always set so
+// OPENAI_API_KEY is never sent to another endpoint.
+func (p *OpenAIProvider) newClient() openai.Client {
+	opts := []option.RequestOption{option.WithAPIKey(p.apiKey), option.WithMaxRetries(0)}
+	if p.baseURL != "" {
+		opts = append(opts, option.WithBaseURL(p.baseURL))
+	}
+
+	return openai.NewClient(opts...)
+}
+
+// Chat implements Provider.
+func (p *OpenAIProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	client := p.newClient()
+
+	completion, err := client.Chat.Completions.New(ctx, p.params(req))
+	if err != nil {
+		return nil, fmt.Errorf("chat completion request failed: %w", err)
+	}
+
+	return p.response(req, completion)
+}
+
+// StreamChat implements Provider.
+func (p *OpenAIProvider) StreamChat(
+	ctx context.Context,
+	req ChatRequest,
+	onText func(text string),
+) (*ChatResponse, error) {
+	client := p.newClient()
+
+	params := p.params(req)
+	params.StreamOptions = openai.ChatCompletionStreamOptionsParam{IncludeUsage: openai.Bool(true)}
+
+	stream := client.Chat.Completions.NewStreaming(ctx, params)
+	defer stream.Close()
+
+	acc := openai.ChatCompletionAccumulator{}
+	var text strings.Builder
+	if onText != nil {
+		onText("")
+	}
+
+	for stream.Next() {
+		chunk := stream.Current()
+		acc.AddChunk(chunk)
+
+		if onText != nil && len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			text.WriteString(chunk.Choices[0].Delta.Content)
+			onText(text.String())
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		return nil, fmt.Errorf("chat completion stream failed: %w", err)
+	}
+
+	return p.response(req, &acc.ChatCompletion)
+}
+
+// params converts a request to Chat Completions parameters. The system blocks
+// are joined into one system message, and a trailing assistant message is
+// followed by continuePrompt.
+func (p *OpenAIProvider) params(req ChatRequest) openai.ChatCompletionNewParams {
+	params := openai.ChatCompletionNewParams{Model: shared.ChatModel(req.Params.Model)}
+	if p.compatible {
+		params.MaxTokens = openai.Int(req.Params.MaxTokens)
+	} else {
+		params.MaxCompletionTokens = openai.Int(req.Params.MaxTokens)
+	}
+	if req.Params.Temperature != nil {
+		params.Temperature = openai.Float(*req.Params.Temperature)
+	}
+
+	system := req.System
+	if req.Tool != nil && p.jsonMode {
+		system = append(system[:len(system):len(system)], jsonModePrompt(req.Tool))
+	}
+	if len(system) > 0 {
+		params.Messages = append(params.Messages, openai.SystemMessage(strings.Join(system, "\n\n")))
+	}
+
+	for _, message := range req.Messages {
+		params.Messages = append(params.Messages, p.message(message))
+	}
+
+	if n := len(req.Messages); n > 0 && req.Messages[n-1].Assistant && req.Messages[n-1].ToolCall == nil {
+		params.Messages = append(params.Messages, openai.UserMessage(continuePrompt))
+	}
+
+	if req.Tool != nil {
+		if p.jsonMode {
+			params.ResponseFormat = openai.ChatCompletionNewParamsResponseFormatUnion{
+				OfJSONObject: &shared.ResponseFormatJSONObjectParam{},
+			}
+		} else {
+			params.Tools = []openai.ChatCompletionToolParam{{
+				Function: shared.FunctionDefinitionParam{
+					Name:        req.Tool.Name,
+					Description: openai.String(req.Tool.Description),
+					Parameters:  req.Tool.schema(),
+				},
+			}}
+			params.ToolChoice = openai.ChatCompletionToolChoiceOptionParamOfChatCompletionNamedToolChoice(
+				openai.ChatCompletionNamedToolChoiceFunctionParam{Name: req.Tool.Name},
+			)
+		}
+	}
+
+	return params
+}
+
+// message converts one turn. In JSON mode tool calls and results are plain
+// text, since the model never saw a function.
+func (p *OpenAIProvider) message(message ChatMessage) openai.ChatCompletionMessageParamUnion {
+	switch {
+	case message.ToolCall != nil && p.jsonMode:
+		return openai.AssistantMessage(string(message.ToolCall.Input))
+	case message.ToolCall != nil:
+		return openai.ChatCompletionMessageParamUnion{OfAssistant: &openai.ChatCompletionAssistantMessageParam{
+			ToolCalls: []openai.ChatCompletionMessageToolCallParam{{
+				ID: message.ToolCall.ID,
+				Function: openai.ChatCompletionMessageToolCallFunctionParam{
+					Name:      message.ToolCall.Name,
+					Arguments: string(message.ToolCall.Input),
+				},
+			}},
+		}}
+	case message.ToolResult != nil && p.jsonMode:
+		return openai.UserMessage(message.ToolResult.Text)
+	case message.ToolResult != nil:
+		return openai.ToolMessage(message.ToolResult.Text, message.ToolResult.CallID)
+	case message.Assistant:
+		return openai.AssistantMessage(message.Text)
+	default:
+		return openai.UserMessage(message.Text)
+	}
+}
+
+// jsonModePrompt tells the model to answer with the tool's input as a JSON
+// object, since JSON mode enforces valid JSON but not a schema.
+func jsonModePrompt(tool *Tool) string {
+	schema, err := json.Marshal(tool.schema())
+	if err != nil {
+		// The schema is built from literals and always encodes
+		schema = []byte("{}")
+	}
+
+	return fmt.Sprintf("Respond only with a JSON object, the input of %s: %s. It must match this JSON schema:\n%s",
+		tool.Name, tool.Description, schema)
+}
+
+// response converts a chat completion. In JSON mode the message content is
+// the tool call's input. OpenAI caches long prompts on its own and counts the
+// cached tokens as part of the prompt.
+func (p *OpenAIProvider) response(req ChatRequest, completion *openai.ChatCompletion) (*ChatResponse, error) {
+	if len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("empty response from %s", p.Name())
+	}
+
+	choice := completion.Choices[0]
+	cached := completion.Usage.PromptTokensDetails.CachedTokens
+	resp := &ChatResponse{
+		Text:            choice.Message.Content,
+		Truncated:       choice.FinishReason == "length",
+		Model:           completion.Model,
+		InputTokens:     completion.Usage.PromptTokens - cached,
+		OutputTokens:    completion.Usage.CompletionTokens,
+		CacheReadTokens: cached,
+	}
+
+	if len(choice.Message.ToolCalls) > 0 {
+		call := choice.Message.ToolCalls[0]
+		input := json.RawMessage(call.Function.Arguments)
+		resp.ToolCall = &ToolCall{ID: call.ID, Name: call.Function.Name, Input: input}
+	} else if req.Tool != nil && p.jsonMode && resp.Text != "" {
+		input := json.RawMessage(stripCodeFence(resp.Text))
+		resp.ToolCall = &ToolCall{ID: completion.ID, Name: req.Tool.Name, Input: input}
+	}
+
+	return resp, nil
+}
+
+// stripCodeFence removes a Markdown code fence some models wrap JSON in.
+func stripCodeFence(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "```") {
+		return text
+	}
+
+	// Drop the opening fence line with its language tag, then the closing one
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[i+1:]
+	}
+
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "```"))
+}
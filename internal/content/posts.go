@@ -0,0 +1,178 @@
+package content
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Post is a published markdown post with its parsed frontmatter.
+type Post struct {
+	Path        string
+	Slug        string
+	Frontmatter Frontmatter
+	Body        string
+}
+
+// LoadPosts reads every markdown post in dir that has parseable frontmatter.
+// Posts without frontmatter are skipped rather than treated as errors, since
+// content directories routinely hold section indexes and stray notes.
+// Results are sorted newest first by their frontmatter date.
+func LoadPosts(dir string) ([]Post, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list posts in %s: %w", dir, err)
+	}
+
+	var posts []Post
+	for _, path := range matches {
+		if strings.HasPrefix(filepath.Base(path), "_") {
+			continue
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read post %s: %w", path, err)
+		}
+
+		fm, body, err := ParseFrontmatter(string(raw))
+		if err != nil {
+			continue
+		}
+
+		posts = append(posts, Post{
+			Path:        path,
+			Slug:        strings.TrimSuffix(filepath.Base(path), ".md"),
+			Frontmatter: *fm,
+			Body:        body,
+		})
+	}
+
+	sort.SliceStable(posts, func(i, j int) bool {
+		return posts[i].Frontmatter.Date > posts[j].Frontmatter.Date
+	})
+
+	return posts, nil
+}
+
+// ExistingTags returns the distinct tags used across posts, most used first.
+// Tags differing only in case are merged under their first spelling.
+func ExistingTags(posts []Post) []string {
+	return NewTagIndex(posts).Tags()
+}
+
+// TagIndex is the tag taxonomy of the published posts. Lookups ignore case.
+// A nil index has no tags.
+type TagIndex struct {
+	// tags holds each tag's first spelling, most used first
+	tags      []string
+	canonical map[string]string
+}
+
+// NewTagIndex indexes the tags in the posts' frontmatter.
+func NewTagIndex(posts []Post) *TagIndex {
+	counts := map[string]int{}
+	spelling := map[string]string{}
+
+	for _, post := range posts {
+		for _, tag := range post.Frontmatter.Tags {
+			tag = strings.TrimSpace(tag)
+			if tag == "" {
+				continue
+			}
+
+			key := strings.ToLower(tag)
+			if _, ok := spelling[key]; !ok {
+				spelling[key] = tag
+			}
+			counts[key]++
+		}
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	tags := make([]string, len(keys))
+	for i, key := range keys {
+		tags[i] = spelling[key]
+	}
+
+	return &TagIndex{tags: tags, canonical: spelling}
+}
+
+// With returns a copy of the index that also knows the given tags, e.g. ones
+// the author requires on every post.
+func (ti *TagIndex) With(tags ...string) *TagIndex {
+	out := &TagIndex{canonical: map[string]string{}}
+	out.tags = append(out.tags, ti.Tags()...)
+	for _, tag := range out.tags {
+		out.canonical[strings.ToLower(tag)] = tag
+	}
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if _, ok := out.canonical[key]; tag == "" || ok {
+			continue
+		}
+		out.canonical[key] = tag
+		out.tags = append(out.tags, tag)
+	}
+
+	return out
+}
+
+// Tags returns the indexed tags, most used first.
+func (ti *TagIndex) Tags() []string {
+	if ti == nil {
+		return nil
+	}
+
+	return ti.tags
+}
+
+// Lookup returns the indexed spelling of tag.
+func (ti *TagIndex) Lookup(tag string) (string, bool) {
+	if ti == nil {
+		return "", false
+	}
+
+	canonical, ok := ti.canonical[strings.ToLower(strings.TrimSpace(tag))]
+
+	return canonical, ok
+}
+
+// Split sorts tags into those already in the index, using the indexed
+// spelling, and new ones. Duplicates and blanks are dropped.
+func (ti *TagIndex) Split(tags []string) ([]string, []string) {
+	var existing, proposed []string
+	seen := map[string]bool{}
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if canonical, ok := ti.Lookup(tag); ok {
+			existing = append(existing, canonical)
+		} else {
+			proposed = append(proposed, tag)
+		}
+	}
+
+	return existing, proposed
+}
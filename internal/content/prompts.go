@@ -1,80 +1,181 @@
 package content
 
-import "fmt"
-
-// FirstDraftSystemPromptMemos is the system prompt for generating first drafts in memos mode.
-const FirstDraftSystemPromptMemos = `You are a first draft writer. Given a raw voice memo transcription, you will:
-- Lightly clean it up, removing verbal tics like "um", "and", "like", and similar filler words
-- Reword things for clarity, but strive to keep the narrative voice as much as possible
-- Organize the ideas, giving them section headings when appropriate, while maintaining the narrative voice
-- Output clean markdown with appropriate heading levels (##, ###)
-- Preserve all footnotes exactly as written - never remove or modify footnote references ([^1]) or definitions
-- Do NOT add Hugo frontmatter - just return the content body
-- This is for a public blog post (memos mode), so organize ideas with clear structure`
-
-// FirstDraftSystemPromptJournal is the system prompt for generating first drafts in journal mode.
-const FirstDraftSystemPromptJournal = `You are a first draft writer. Given a raw journal voice memo, you will:
-- Lightly clean it up, removing verbal tics like "um", "and", "like", and similar filler words
-- Reword things for clarity, but keep the personal, conversational tone
-- Light organization with headings only when natural, preserving the journal's narrative flow
-- Output clean markdown with appropriate heading levels (##, ###)
-- Preserve all footnotes exactly as written - never remove or modify footnote references ([^1]) or definitions
-- Do NOT add Hugo frontmatter - just return the content body
-- This is a personal journal entry, so maintain the intimate, reflective voice`
-
-// CopyEditSystemPromptMemos generates the system prompt for copy editing in memos mode (full frontmatter).
-func CopyEditSystemPromptMemos(currentDate string) string {
-	return fmt.Sprintf(`You are a copy editor. Given a blog post draft, you will:
-- Polish grammar, punctuation, and style consistency
-- Fix any typos or awkward phrasing
-- Ensure proper markdown formatting
-- Preserve all footnotes exactly as written - never remove or modify footnote references ([^1]) or definitions
-- Generate appropriate Hugo frontmatter with title, date, tags, and metadata
-- The frontmatter must include:
-  - title: The blog post title (quoted string)
-  - date: %s (this is the current date, use it exactly as provided)
-  - author: James
-  - tags: An array of relevant tags, e.g. ["AI Assisted Dev", "Working In The Open"]
-  - voiceBased: true
-  - pinned: false
-  - draft: false
-- Always end the post content with the byline shortcode:
-  ---
-  {{< byline >}}
-
-When you are done editing, use the save_copy_edit tool to provide:
-1. title: The blog post title (as a plain string, extracted from the frontmatter you created)
-2. markdown: The complete markdown file including frontmatter and byline (raw markdown, no code fences)
-3. changes: A list of bullet-point strings describing each change you made, such as:
-   - "Fixed typo in paragraph 2: 'teh' → 'the'"
-   - "Added section heading: 'Implementation Details'"
-   - "Reorganized conclusion for better flow"
-   - "Added tags: ['Go', 'CLI Tools']"`, currentDate)
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Prompt template names. Each is a file "<name>.tmpl" in the prompts directory.
+const (
+	PromptFirstDraftMemos   = "first-draft-memos"
+	PromptFirstDraftJournal = "first-draft-journal"
+	PromptCopyEditMemos     = "copy-edit-memos"
+	PromptCopyEditJournal   = "copy-edit-journal"
+	PromptTranslate         = "translate"
+	PromptRevise            = "revise"
+	PromptSocial            = "social"
+)
+
+const promptExt = ".tmpl"
+
+//go:embed prompts/*.tmpl
+var builtinPrompts embed.FS
+
+// PromptData holds the variables available to prompt templates.
+type PromptData struct {
+	// Author is the byline name. Empty omits the author field.
+	Author string
+	// Date is the post date (copy edit only).
+	Date string
+	Mode Mode
+	// Frontmatter lists the fields the mode requires (copy edit only).
+	Frontmatter []string
+	// Tags must appear on the post.
+	Tags []string
+	// ExistingTags are tags already used on the blog, most used first.
+	ExistingTags []string
+}
+
+// Prompts renders system prompts from text/template files.
+type Prompts struct {
+	templates map[string]*template.Template
+}
+
+var promptFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// DefaultPrompts returns the built-in prompts.
+func DefaultPrompts() *Prompts {
+	prompts, err := loadPrompts(builtinPrompts, "prompts")
+	if err != nil {
+		panic("invalid built-in prompt: " + err.Error())
+	}
+
+	return prompts
+}
+
+// LoadPrompts returns the built-in prompts, overridden by any template files
+// of the same name in dir. Templates with other names are added for use by
+// custom modes. A missing dir yields the built-ins.
+func LoadPrompts(dir string) (*Prompts, error) {
+	prompts := DefaultPrompts()
+
+	overrides, err := loadPrompts(os.DirFS(dir), ".")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return prompts, nil
+		}
+
+		return nil, fmt.Errorf("failed to load prompts from %s: %w", dir, err)
+	}
+
+	for name, tmpl := range overrides.templates {
+		prompts.templates[name] = tmpl
+	}
+
+	return prompts, nil
 }
 
-// CopyEditSystemPromptJournal generates the system prompt for copy editing in journal mode (minimal frontmatter).
-func CopyEditSystemPromptJournal(currentDate string) string {
-	return fmt.Sprintf(`You are a copy editor. Given a journal entry draft, you will:
-- Polish grammar, punctuation, and style consistency
-- Fix any typos or awkward phrasing
-- Ensure proper markdown formatting
-- Preserve all footnotes exactly as written - never remove or modify footnote references ([^1]) or definitions
-- Generate minimal Hugo frontmatter for a personal journal entry
-- The frontmatter must include (minimal fields only):
-  - title: The post title (quoted string)
-  - date: %s (this is the current date, use it exactly as provided)
-  - author: James
-  - draft: false
-- Do NOT include tags, voiceBased, or pinned fields - this is a personal journal entry
-- Always end the post content with the byline shortcode:
-  ---
-  {{< byline >}}
-
-When you are done editing, use the save_copy_edit tool to provide:
-1. title: The post title (as a plain string, extracted from the frontmatter you created)
-2. markdown: The complete markdown file including frontmatter and byline (raw markdown, no code fences)
-3. changes: A list of bullet-point strings describing each change you made, such as:
-   - "Fixed typo in paragraph 2: 'teh' → 'the'"
-   - "Reorganized for clarity"
-   - "Simplified phrasing"`, currentDate)
+func loadPrompts(fsys fs.FS, dir string) (*Prompts, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt templates: %w", err)
+	}
+
+	prompts := &Prompts{templates: make(map[string]*template.Template)}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != promptExt {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template %s: %w", entry.Name(), err)
+		}
+
+		name := strings.TrimSuffix(entry.Name(), promptExt)
+		tmpl, err := template.New(name).Funcs(promptFuncs).Option("missingkey=error").Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse prompt template %s: %w", entry.Name(), err)
+		}
+
+		prompts.templates[name] = tmpl
+	}
+
+	return prompts, nil
+}
+
+// Has reports whether a template with the given name exists.
+func (p *Prompts) Has(name string) bool {
+	_, ok := p.templates[name]
+
+	return ok
+}
+
+// Render executes the named prompt template with data.
+func (p *Prompts) Render(name string, data PromptData) (string, error) {
+	tmpl, ok := p.templates[name]
+	if !ok {
+		return "", fmt.Errorf("unknown prompt template %q", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt template %s: %w", name, err)
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// PromptNames returns the names of the built-in prompt templates.
+func PromptNames() []string {
+	builtins := DefaultPrompts().templates
+
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// DumpPrompts writes the built-in prompt templates to dir as a starting point
+// for customization. Existing files are kept unless overwrite is set.
+// Returns the paths written.
+func DumpPrompts(dir string, overwrite bool) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create prompts directory %s: %w", dir, err)
+	}
+
+	var written []string
+	for _, name := range PromptNames() {
+		file := filepath.Join(dir, name+promptExt)
+		if _, err := os.Stat(file); err == nil && !overwrite {
+			continue
+		}
+
+		data, err := builtinPrompts.ReadFile("prompts/" + name + promptExt)
+		if err != nil {
+			return written, fmt.Errorf("failed to read built-in prompt %s: %w", name, err)
+		}
+
+		//nolint:gosec // Prompt templates are meant to be edited by the user
+		if err := os.WriteFile(file, data, 0o644); err != nil {
+			return written, fmt.Errorf("failed to write prompt template %s: %w", file, err)
+		}
+		written = append(written, file)
+	}
+
+	return written, nil
 }
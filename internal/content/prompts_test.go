@@ -0,0 +1,125 @@
+package content_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPrompts_CopyEditMemos(t *testing.T) {
+	prompt, err := content.DefaultPrompts().Render(content.PromptCopyEditMemos, content.PromptData{
+		Author:       "Sam",
+		Date:         "2026-10-18",
+		Tags:         []string{"Go"},
+		ExistingTags: []string{"Go", "CLI Tools"},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "  - date: 2026-10-18 (this is the current date")
+	assert.Contains(t, prompt, "  - author: Sam\n  - tags:")
+	assert.Contains(t, prompt, "Always include these tags: Go\n")
+	assert.Contains(t, prompt, "Choose from the tags already used on the blog: Go, CLI Tools\n")
+	assert.Contains(t, prompt, "{{< byline >}}")
+}
+
+func TestDefaultPrompts_NoAuthor(t *testing.T) {
+	prompt, err := content.DefaultPrompts().Render(content.PromptCopyEditJournal, content.PromptData{
+		Date: "2026-10-18",
+	})
+
+	require.NoError(t, err)
+	assert.NotContains(t, prompt, "author:")
+	assert.Contains(t, prompt, "use it exactly as provided)\n  - draft: false")
+}
+
+func TestDefaultPrompts_Revise(t *testing.T) {
+	prompt, err := content.DefaultPrompts().Render(content.PromptRevise, content.PromptData{
+		Mode:        content.ModeJournal,
+		Frontmatter: []string{"title", "date", "draft"},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "revising a finished journal post")
+	assert.Contains(t, prompt, "Keep the frontmatter with these fields: title, date, draft, updating")
+}
+
+func TestLoadPrompts_Override(t *testing.T) {
+	dir := t.TempDir()
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "first-draft-memos.tmpl"),
+		[]byte("Draft for {{.Author}} in {{.Mode}} mode.\n"), 0o644))
+
+	prompts, err := content.LoadPrompts(dir)
+	require.NoError(t, err)
+
+	data := content.PromptData{Author: "Sam", Mode: content.ModeMemos}
+	prompt, err := prompts.Render(content.PromptFirstDraftMemos, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Draft for Sam in memos mode.", prompt)
+
+	// Templates without an override fall back to the built-ins
+	prompt, err = prompts.Render(content.PromptFirstDraftJournal, content.PromptData{})
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "You are a first draft writer")
+}
+
+func TestLoadPrompts_CustomTemplate(t *testing.T) {
+	dir := t.TempDir()
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "copy-edit-standup.tmpl"),
+		[]byte("Fields: {{join .Frontmatter \", \"}}\n"), 0o644))
+
+	prompts, err := content.LoadPrompts(dir)
+	require.NoError(t, err)
+	assert.True(t, prompts.Has("copy-edit-standup"))
+	assert.False(t, prompts.Has("copy-edit-memo"))
+
+	prompt, err := prompts.Render("copy-edit-standup", content.PromptData{Frontmatter: []string{"title", "date"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Fields: title, date", prompt)
+}
+
+func TestLoadPrompts_MissingDir(t *testing.T) {
+	prompts, err := content.LoadPrompts(filepath.Join(t.TempDir(), "missing"))
+
+	require.NoError(t, err)
+	_, err = prompts.Render(content.PromptTranslate, content.PromptData{})
+	assert.NoError(t, err)
+}
+
+func TestLoadPrompts_Errors(t *testing.T) {
+	t.Run("parse error", func(t *testing.T) {
+		dir := t.TempDir()
+		//nolint:gosec // Test file
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "translate.tmpl"), []byte("{{.Author"), 0o644))
+
+		_, err := content.LoadPrompts(dir)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "translate.tmpl")
+	})
+}
+
+func TestDumpPrompts(t *testing.T) {
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "translate.tmpl")
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(custom, []byte("custom"), 0o644))
+
+	written, err := content.DumpPrompts(dir, false)
+
+	require.NoError(t, err)
+	assert.Len(t, written, len(content.PromptNames())-1, "Existing templates should be kept")
+
+	data, err := os.ReadFile(custom)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", string(data))
+
+	// Dumped templates load back as valid overrides
+	_, err = content.LoadPrompts(dir)
+	require.NoError(t, err)
+}
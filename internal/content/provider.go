@@ -0,0 +1,139 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Provider names accepted by NewProvider.
+const (
+	ProviderAnthropic        = "anthropic"
+	ProviderOpenAI           = "openai"
+	ProviderOpenAICompatible = "openai-compatible"
+)
+
+// Provider sends chat requests to an LLM API. The Writer builds requests in
+// this provider-neutral form, so prompts, validation and retries work the same
+// for every API.
+type Provider interface {
+	// Name identifies the API in error messages, e.g. "Anthropic API".
+	Name() string
+	// DefaultModel is used by phases without a configured model.
+	DefaultModel() string
+	// Validate reports configuration that makes every request fail, such as
+	// a missing API key.
+	Validate() error
+	// Chat sends a request and waits for the whole response.
+	Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error)
+	// StreamChat sends a request and calls onText, when non-nil, with the
+	// text generated so far as tokens arrive.
+	StreamChat(ctx context.Context, req ChatRequest, onText func(text string)) (*ChatResponse, error)
+}
+
+// ProviderConfig selects and configures a Provider.
+type ProviderConfig struct {
+	// Name is one of ProviderAnthropic, ProviderOpenAI or
+	// ProviderOpenAICompatible. Empty uses ProviderAnthropic.
+	Name   string
+	APIKey string
+	// BaseURL is the endpoint of an OpenAI-compatible API. Empty uses
+	// DefaultCompatibleBaseURL.
+	BaseURL string
+	// JSONMode requests structured output as a JSON object instead of a
+	// function call, for OpenAI-compatible models without tool support.
+	JSONMode bool
+}
+
+// NewProvider creates the provider named in config.
+func NewProvider(config ProviderConfig) (Provider, error) {
+	switch config.Name {
+	case "", ProviderAnthropic:
+		return NewAnthropicProvider(config.APIKey), nil
+	case ProviderOpenAI:
+		provider := NewOpenAIProvider(config.APIKey)
+		provider.jsonMode = config.JSONMode
+
+		return provider, nil
+	case ProviderOpenAICompatible:
+		return NewOpenAICompatibleProvider(config.BaseURL, config.APIKey, config.JSONMode), nil
+	default:
+		return nil, fmt.Errorf("invalid provider %q: must be one of %s, %s, %s",
+			config.Name, ProviderAnthropic, ProviderOpenAI, ProviderOpenAICompatible)
+	}
+}
+
+// ChatRequest is a provider-neutral chat request.
+type ChatRequest struct {
+	// System holds the system prompt blocks in order.
+	System   []string
+	Messages []ChatMessage
+	Params   GenerationParams
+	// Tool, when set, must be called by the model; its input is returned in
+	// ChatResponse.ToolCall.
+	Tool *Tool
+	// CacheSystem marks the system blocks as a prefix shared by many requests
+	// and worth caching. Providers that cache prompts automatically ignore it.
+	CacheSystem bool
+}
+
+// ChatMessage is one turn of a conversation. A message holds text, a call of
+// the request's tool, or the result of that call.
+type ChatMessage struct {
+	// Assistant marks a message written by the model; others are the user's.
+	// A trailing assistant text message is continued by the model.
+	Assistant  bool
+	Text       string
+	ToolCall   *ToolCall
+	ToolResult *ToolResult
+}
+
+// ToolCall is the model's call of a tool.
+type ToolCall struct {
+	ID    string
+	Name  string
+	Input json.RawMessage
+}
+
+// ToolResult answers a ToolCall.
+type ToolResult struct {
+	CallID  string
+	Text    string
+	IsError bool
+}
+
+// Tool describes structured output as a function the model must call.
+type Tool struct {
+	Name        string
+	Description string
+	// Properties is the JSON schema of each input field.
+	Properties map[string]any
+	Required   []string
+}
+
+// schema returns the JSON schema of the tool's input.
+func (t *Tool) schema() map[string]any {
+	return map[string]any{
+		"type":       "object",
+		"properties": t.Properties,
+		"required":   t.Required,
+	}
+}
+
+// ChatResponse is a provider-neutral chat response.
+type ChatResponse struct {
+	Text     string
+	ToolCall *ToolCall
+	// Truncated reports a response cut off at the max token limit.
+	Truncated bool
+	Model     string
+	// InputTokens counts the input not read from or written to the prompt
+	// cache.
+	InputTokens  int64
+	OutputTokens int64
+	// CacheReadTokens are input tokens served from the prompt cache, a hit.
+	CacheReadTokens int64
+	// CacheWriteTokens are input tokens written to the prompt cache after a
+	// miss.
+	CacheWriteTokens int64
+}
@@ -0,0 +1,181 @@
+package content
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repairConversation is a copy edit request after one failed attempt.
+func repairConversation() ChatRequest {
+	call := &ToolCall{ID: "call_1", Name: "save_copy_edit", Input: json.RawMessage(`{"markdown":"broken"}`)}
+
+	return ChatRequest{
+		System: []string{"prompt", "examples"},
+		Messages: []ChatMessage{
+			{Text: "draft"},
+			{Assistant: true, ToolCall: call},
+			{ToolResult: &ToolResult{CallID: "call_1", Text: "fix it", IsError: true}},
+		},
+		Params:      GenerationParams{Model: "model", MaxTokens: 1000},
+		Tool:        copyEditTool(),
+		CacheSystem: true,
+	}
+}
+
+// encode marshals SDK params the way they are sent.
+func encode(t *testing.T, params any) map[string]any {
+	t.Helper()
+
+	raw, err := json.Marshal(params)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+
+	return decoded
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(ProviderConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultModel, provider.DefaultModel())
+	require.ErrorContains(t, provider.Validate(), "ANTHROPIC_API_KEY")
+
+	provider, err = NewProvider(ProviderConfig{Name: ProviderOpenAICompatible})
+	require.NoError(t, err)
+	assert.Equal(t, "OpenAI-compatible API at "+DefaultCompatibleBaseURL, provider.Name())
+	require.NoError(t, provider.Validate(), "Local endpoints need no key")
+
+	_, err = NewProvider(ProviderConfig{Name: "gemini"})
+	assert.ErrorContains(t, err, "invalid provider")
+}
+
+func TestAnthropicParams(t *testing.T) {
+	params := anthropicParams(repairConversation())
+
+	assert.Equal(t, anthropic.Model("model"), params.Model)
+	assert.Equal(t, int64(1000), params.MaxTokens)
+	assert.False(t, params.Temperature.Valid(), "Temperature should be left to the API default")
+	require.Len(t, params.System, 2)
+	assert.Equal(t, "examples", params.System[1].Text)
+	assert.Equal(t, "ephemeral", string(params.System[0].CacheControl.Type), "Both system blocks are cached")
+	assert.Equal(t, "ephemeral", string(params.System[1].CacheControl.Type))
+
+	require.Len(t, params.Messages, 3)
+	assert.Equal(t, anthropic.MessageParamRoleAssistant, params.Messages[1].Role)
+	assert.Equal(t, "call_1", params.Messages[1].Content[0].OfToolUse.ID)
+
+	toolResult := params.Messages[2].Content[0].OfToolResult
+	require.NotNil(t, toolResult)
+	assert.True(t, toolResult.IsError.Value)
+
+	require.Len(t, params.Tools, 1)
+	assert.Equal(t, "save_copy_edit", params.ToolChoice.OfTool.Name)
+}
+
+func TestAnthropicResponse(t *testing.T) {
+	var message anthropic.Message
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"model": "claude-haiku-4-5",
+		"content": [
+			{"type": "text", "text": "Saving."},
+			{"type": "tool_use", "id": "toolu_1", "name": "save_copy_edit", "input": {"markdown": "post"}}
+		],
+		"stop_reason": "max_tokens",
+		"usage": {"input_tokens": 10, "output_tokens": 5, "cache_read_input_tokens": 2000,
+			"cache_creation_input_tokens": 300}
+	}`), &message))
+
+	resp := anthropicResponse(&message)
+
+	assert.Equal(t, "Saving.", resp.Text)
+	assert.True(t, resp.Truncated)
+	assert.Equal(t, "claude-haiku-4-5", resp.Model)
+	assert.Equal(t, int64(10), resp.InputTokens)
+	assert.Equal(t, int64(2000), resp.CacheReadTokens)
+	assert.Equal(t, int64(300), resp.CacheWriteTokens)
+	require.NotNil(t, resp.ToolCall)
+	assert.JSONEq(t, `{"markdown": "post"}`, string(resp.ToolCall.Input))
+}
+
+func TestOpenAIParams_FunctionCalling(t *testing.T) {
+	params := encode(t, NewOpenAIProvider("key").params(repairConversation()))
+
+	assert.InDelta(t, 1000, params["max_completion_tokens"], 0)
+	assert.NotContains(t, params, "response_format")
+
+	messages, _ := params["messages"].([]any)
+	require.Len(t, messages, 4)
+	assert.Equal(t, map[string]any{"role": "system", "content": "prompt\n\nexamples"}, messages[0])
+	assert.Equal(t, "call_1", messages[2].(map[string]any)["tool_calls"].([]any)[0].(map[string]any)["id"])
+	assert.Equal(t, map[string]any{"role": "tool", "content": "fix it", "tool_call_id": "call_1"}, messages[3])
+
+	choice, _ := params["tool_choice"].(map[string]any)
+	assert.Equal(t, map[string]any{"name": "save_copy_edit"}, choice["function"])
+}
+
+func TestOpenAIParams_JSONMode(t *testing.T) {
+	provider := NewOpenAICompatibleProvider("http://localhost:8080/v1", "", true)
+	params := encode(t, provider.params(repairConversation()))
+
+	assert.InDelta(t, 1000, params["max_tokens"], 0, "Compatible endpoints get the older max_tokens")
+	assert.Equal(t, map[string]any{"type": "json_object"}, params["response_format"])
+	assert.NotContains(t, params, "tools")
+
+	messages, _ := params["messages"].([]any)
+	require.Len(t, messages, 4)
+	assert.Contains(t, messages[0].(map[string]any)["content"], "Respond only with a JSON object")
+	assert.Equal(t, map[string]any{"role": "assistant", "content": `{"markdown":"broken"}`}, messages[2])
+	assert.Equal(t, map[string]any{"role": "user", "content": "fix it"}, messages[3])
+}
+
+func TestOpenAIParams_Continuation(t *testing.T) {
+	req := ChatRequest{Messages: []ChatMessage{{Text: "transcript"}, {Assistant: true, Text: "First half"}}}
+
+	messages, _ := encode(t, NewOpenAIProvider("key").params(req))["messages"].([]any)
+
+	require.Len(t, messages, 3)
+	assert.Equal(t, map[string]any{"role": "user", "content": continuePrompt}, messages[2])
+}
+
+// fencedJSON is JSON mode output wrapped in a code fence, as some models do.
+const fencedJSON = "```json\n{\"markdown\": \"post\"}\n```"
+
+func TestOpenAIResponse(t *testing.T) {
+	raw, err := json.Marshal(map[string]any{
+		"id":      "chatcmpl-1",
+		"model":   "llama3.1",
+		"choices": []any{map[string]any{"message": map[string]any{"content": fencedJSON}, "finish_reason": "stop"}},
+		"usage": map[string]any{
+			"prompt_tokens":         12,
+			"completion_tokens":     3,
+			"prompt_tokens_details": map[string]any{"cached_tokens": 8},
+		},
+	})
+	require.NoError(t, err)
+
+	var completion openai.ChatCompletion
+	require.NoError(t, json.Unmarshal(raw, &completion))
+	req := ChatRequest{Tool: copyEditTool()}
+
+	resp, err := NewOpenAICompatibleProvider("", "", true).response(req, &completion)
+	require.NoError(t, err)
+
+	assert.False(t, resp.Truncated)
+	assert.Equal(t, int64(4), resp.InputTokens, "Cached tokens are counted apart")
+	assert.Equal(t, int64(8), resp.CacheReadTokens)
+	require.NotNil(t, resp.ToolCall, "JSON mode content is the tool call")
+	assert.Equal(t, "save_copy_edit", resp.ToolCall.Name)
+	assert.JSONEq(t, `{"markdown": "post"}`, string(resp.ToolCall.Input))
+
+	completion.Choices[0].FinishReason = "length"
+	resp, err = NewOpenAIProvider("key").response(ChatRequest{}, &completion)
+	require.NoError(t, err)
+	assert.True(t, resp.Truncated)
+	assert.Nil(t, resp.ToolCall, "Without JSON mode only function calls count")
+}
@@ -0,0 +1,372 @@
+package content
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Kinds of personal details the Redactor replaces, used in placeholders
+// such as [NAME_1].
+const (
+	redactEmail   = "EMAIL"
+	redactPhone   = "PHONE"
+	redactAddress = "ADDRESS"
+	redactName    = "NAME"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+
+	// phonePattern matches international numbers starting with + and North
+	// American numbers like (206) 555-0134 or 206.555.0134.
+	phonePattern = regexp.MustCompile(
+		`\+\d{1,3}(?:[ .-]?\(?\d{2,4}\)?){2,5}|(?:\(\d{3}\) ?|\b\d{3}[ .-])\d{3}[ .-]\d{4}\b`)
+
+	// addressPattern matches street addresses like 1234 Old Mill Road, Apt 5:
+	// a house number, capitalized street name words and a street type.
+	addressPattern = regexp.MustCompile(
+		`\b\d{1,6} (?:[A-Z][A-Za-z0-9'-]*\.? ){1,4}` +
+			`(?i:street|st|avenue|ave|road|rd|boulevard|blvd|lane|ln|drive|dr|court|ct|way|place|pl|` +
+			`terrace|ter|circle|cir|parkway|pkwy|highway|hwy)\b\.?` +
+			`(?:,? (?i:apt|apartment|suite|unit)\.? ?#?[A-Za-z0-9-]+|,? #[A-Za-z0-9-]+)?`)
+
+	placeholderPattern = regexp.MustCompile(`\[(?:EMAIL|PHONE|ADDRESS|NAME)_\d+\]`)
+)
+
+// redactionNote tells the model to keep the placeholders of a redacted
+// request.
+const redactionNote = "Personal details in the text were replaced with placeholders such as " +
+	"[NAME_1], [EMAIL_1], [PHONE_1] and [ADDRESS_1]. Keep every placeholder exactly as written, " +
+	"brackets included, wherever the detail belongs; never guess, expand or translate them."
+
+// Redactor replaces emails, phone numbers, street addresses and listed
+// names with placeholders, and puts the real values back. A value keeps its
+// placeholder for the Redactor's lifetime, so the first draft and copy edit
+// of a memo see the same [NAME_1]. It is safe for concurrent use.
+type Redactor struct {
+	// names matches a listed name, group 2, between non-word characters
+	names *regexp.Regexp
+
+	mu sync.Mutex
+	// placeholders maps kind and value to the value's placeholder
+	placeholders map[string]string
+	values       map[string]string
+	counts       map[string]int
+}
+
+// NewRedactor creates a Redactor that also replaces the given names, e.g.
+// family members. Names match whole words, ignoring case, and are restored
+// as written in the text.
+func NewRedactor(names []string) *Redactor {
+	r := &Redactor{
+		placeholders: map[string]string{},
+		values:       map[string]string{},
+		counts:       map[string]int{},
+	}
+
+	seen := map[string]bool{}
+	var alternatives []string
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+
+		seen[strings.ToLower(name)] = true
+		alternatives = append(alternatives, regexp.QuoteMeta(name))
+	}
+
+	if len(alternatives) > 0 {
+		// Longer names first, so "Anna Smith" wins over "Anna". RE2's \b only
+		// knows ASCII letters, so the boundaries are spelled out for names like
+		// "José".
+		sort.SliceStable(alternatives, func(i, j int) bool { return len(alternatives[i]) > len(alternatives[j]) })
+		r.names = regexp.MustCompile(`(^|[^\p{L}\p{N}_])((?i:` + strings.Join(alternatives, "|") +
+			`))($|[^\p{L}\p{N}_])`)
+	}
+
+	return r
+}
+
+// LoadRedactionNames reads the names to redact from the file at path, one
+// per line. Blank lines and lines starting with # are ignored. A missing file
+// yields no names.
+func LoadRedactionNames(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open redaction names %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read redaction names %s: %w", path, err)
+	}
+
+	return names, nil
+}
+
+// Redact replaces the personal details in text with placeholders. Reports
+// how many were replaced.
+func (r *Redactor) Redact(text string) (string, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	replaced := 0
+	replace := func(kind string, pattern *regexp.Regexp) {
+		text = pattern.ReplaceAllStringFunc(text, func(match string) string {
+			replaced++
+			return r.placeholder(kind, match)
+		})
+	}
+
+	// Emails and addresses first, so their words and digits are not taken for
+	// names or phone numbers
+	replace(redactEmail, emailPattern)
+	replace(redactAddress, addressPattern)
+	replace(redactPhone, phonePattern)
+	if r.names != nil {
+		text = r.redactNames(text, &replaced)
+	}
+
+	return text, replaced
+}
+
+// redactNames replaces the listed names in text, counting them in replaced.
+// A match uses up the character after the name, so names only a space apart
+// take another pass. The caller holds r.mu.
+func (r *Redactor) redactNames(text string, replaced *int) string {
+	for {
+		matches := r.names.FindAllStringSubmatchIndex(text, -1)
+		if len(matches) == 0 {
+			return text
+		}
+
+		var sb strings.Builder
+		last := 0
+		for _, match := range matches {
+			start, end := match[4], match[5]
+			sb.WriteString(text[last:start])
+			sb.WriteString(r.placeholder(redactName, text[start:end]))
+			last = end
+		}
+		sb.WriteString(text[last:])
+
+		text = sb.String()
+		*replaced += len(matches)
+	}
+}
+
+// placeholder returns the value's placeholder, assigning the next one of its
+// kind the first time. The caller holds r.mu.
+func (r *Redactor) placeholder(kind, value string) string {
+	key := kind + "\x00" + value
+	if placeholder, ok := r.placeholders[key]; ok {
+		return placeholder
+	}
+
+	r.counts[kind]++
+	placeholder := fmt.Sprintf("[%s_%d]", kind, r.counts[kind])
+	r.placeholders[key] = placeholder
+	r.values[placeholder] = value
+
+	return placeholder
+}
+
+// Restore puts the real values back in place of the placeholders in text.
+// Unknown placeholders are left as they are.
+func (r *Redactor) Restore(text string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if value, ok := r.values[placeholder]; ok {
+			return value
+		}
+
+		return placeholder
+	})
+}
+
+// redactJSON redacts the string values of a tool call's JSON input. Input
+// that is not valid JSON is redacted as text.
+func (r *Redactor) redactJSON(input json.RawMessage) (json.RawMessage, int) {
+	replaced := 0
+
+	output, ok := mapJSONStrings(input, func(s string) string {
+		s, n := r.Redact(s)
+		replaced += n
+
+		return s
+	})
+	if !ok {
+		text, n := r.Redact(string(input))
+		return json.RawMessage(text), n
+	}
+
+	return output, replaced
+}
+
+// restoreJSON restores the string values of a tool call's JSON input, so
+// the values are escaped properly. Input that is not valid JSON is restored
+// as text.
+func (r *Redactor) restoreJSON(input json.RawMessage) json.RawMessage {
+	output, ok := mapJSONStrings(input, r.Restore)
+	if !ok {
+		return json.RawMessage(r.Restore(string(input)))
+	}
+
+	return output
+}
+
+// mapJSONStrings applies fn to every string value in the JSON document.
+// Reports false when input is not valid JSON.
+func mapJSONStrings(input json.RawMessage, fn func(string) string) (json.RawMessage, bool) {
+	var doc any
+	if err := json.Unmarshal(input, &doc); err != nil {
+		return nil, false
+	}
+
+	var walk func(v any) any
+	walk = func(v any) any {
+		switch v := v.(type) {
+		case string:
+			return fn(v)
+		case []any:
+			for i := range v {
+				v[i] = walk(v[i])
+			}
+		case map[string]any:
+			for k := range v {
+				v[k] = walk(v[k])
+			}
+		}
+
+		return v
+	}
+
+	output, err := json.Marshal(walk(doc))
+	if err != nil {
+		return nil, false
+	}
+
+	return output, true
+}
+
+// redactingProvider redacts the messages of every request before passing it
+// on and restores the real values in the response.
+type redactingProvider struct {
+	Provider
+
+	redactor *Redactor
+}
+
+// NewRedactingProvider wraps provider so personal details never reach the
+// API: the messages of each request are redacted with redactor, and the
+// placeholders in the response text and tool call are replaced with the real
+// values again. System prompts are sent as they are.
+func NewRedactingProvider(provider Provider, redactor *Redactor) Provider {
+	return &redactingProvider{Provider: provider, redactor: redactor}
+}
+
+// Chat implements Provider.
+func (p *redactingProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	resp, err := p.Provider.Chat(ctx, p.redact(req))
+	if err != nil {
+		return nil, err //nolint:wrapcheck // the wrapped provider wraps its own errors
+	}
+
+	return p.restore(resp), nil
+}
+
+// StreamChat implements Provider. onText receives the text so far with the
+// real values.
+func (p *redactingProvider) StreamChat(
+	ctx context.Context,
+	req ChatRequest,
+	onText func(text string),
+) (*ChatResponse, error) {
+	var restoringOnText func(string)
+	if onText != nil {
+		restoringOnText = func(text string) { onText(p.redactor.Restore(text)) }
+	}
+
+	resp, err := p.Provider.StreamChat(ctx, p.redact(req), restoringOnText)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // the wrapped provider wraps its own errors
+	}
+
+	return p.restore(resp), nil
+}
+
+// redact returns the request with its messages redacted, adding a note on
+// the placeholders to the system prompt when any were used.
+func (p *redactingProvider) redact(req ChatRequest) ChatRequest {
+	replaced := 0
+	messages := make([]ChatMessage, len(req.Messages))
+	for i, msg := range req.Messages {
+		var n int
+		msg.Text, n = p.redactor.Redact(msg.Text)
+		replaced += n
+
+		if msg.ToolCall != nil {
+			call := *msg.ToolCall
+			call.Input, n = p.redactor.redactJSON(call.Input)
+			replaced += n
+			msg.ToolCall = &call
+		}
+
+		if msg.ToolResult != nil {
+			result := *msg.ToolResult
+			result.Text, n = p.redactor.Redact(result.Text)
+			replaced += n
+			msg.ToolResult = &result
+		}
+
+		messages[i] = msg
+	}
+	req.Messages = messages
+
+	if replaced > 0 {
+		slog.Debug("Redacted personal details", "count", replaced)
+		req.System = append(append([]string{}, req.System...), redactionNote)
+	}
+
+	return req
+}
+
+// restore returns the response with the real values in place of the
+// placeholders.
+func (p *redactingProvider) restore(resp *ChatResponse) *ChatResponse {
+	restored := *resp
+	restored.Text = p.redactor.Restore(resp.Text)
+
+	if resp.ToolCall != nil {
+		call := *resp.ToolCall
+		call.Input = p.redactor.restoreJSON(call.Input)
+		restored.ToolCall = &call
+	}
+
+	return &restored
+}
@@ -0,0 +1,160 @@
+package content_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor_RedactAndRestore(t *testing.T) {
+	redactor := content.NewRedactor([]string{"Anna", "Anna Smith", "Oliver"})
+
+	text := "Anna Smith called from (206) 555-0134 about the party at 1234 Old Mill Road, Apt 5. " +
+		"Email anna@example.com or ring +44 20 7946 0958. Then oliver and Anna went home in 2025-11-18 traffic."
+
+	redacted, count := redactor.Redact(text)
+
+	assert.Equal(t, "[NAME_1] called from [PHONE_1] about the party at [ADDRESS_1]. "+
+		"Email [EMAIL_1] or ring [PHONE_2]. Then [NAME_2] and [NAME_3] went home in 2025-11-18 traffic.", redacted)
+	assert.Equal(t, 7, count)
+
+	assert.Equal(t, text, redactor.Restore(redacted))
+}
+
+func TestRedactor_AccentedNames(t *testing.T) {
+	redactor := content.NewRedactor([]string{"José", "Élodie", "Jürgen Groß"})
+
+	text := "José, Élodie and Jürgen Groß met Josébel and élodie."
+	redacted, count := redactor.Redact(text)
+
+	assert.Equal(t, "[NAME_1], [NAME_2] and [NAME_3] met Josébel and [NAME_4].", redacted)
+	assert.Equal(t, 4, count)
+	assert.Equal(t, text, redactor.Restore(redacted))
+}
+
+func TestRedactor_AdjacentNames(t *testing.T) {
+	redactor := content.NewRedactor([]string{"Anna", "Ben", "Cy"})
+
+	redacted, count := redactor.Redact("Anna Ben Cy")
+
+	assert.Equal(t, "[NAME_1] [NAME_3] [NAME_2]", redacted)
+	assert.Equal(t, 3, count)
+}
+
+func TestRedactor_RestoresCaseAsWritten(t *testing.T) {
+	redactor := content.NewRedactor([]string{"Will"})
+
+	text := "I will ask Will."
+	redacted, _ := redactor.Redact(text)
+
+	assert.Equal(t, "I [NAME_1] ask [NAME_2].", redacted)
+	assert.Equal(t, text, redactor.Restore(redacted))
+}
+
+func TestRedactor_StablePlaceholders(t *testing.T) {
+	redactor := content.NewRedactor([]string{"Oliver"})
+
+	first, _ := redactor.Redact("Oliver is six.")
+	second, _ := redactor.Redact("Ask Oliver, 206-555-0134.")
+
+	assert.Equal(t, "[NAME_1] is six.", first)
+	assert.Equal(t, "Ask [NAME_1], [PHONE_1].", second)
+
+	// Placeholders the redactor never handed out stay as they are
+	assert.Equal(t, "Oliver and [NAME_9]", redactor.Restore("[NAME_1] and [NAME_9]"))
+}
+
+func TestRedactor_LeavesOrdinaryTextAlone(t *testing.T) {
+	redactor := content.NewRedactor(nil)
+
+	text := "On 2025-11-18 I ran 3 Big Ways to Go faster, version 1.25.3, and paid $1,234.56."
+	redacted, count := redactor.Redact(text)
+
+	assert.Equal(t, text, redacted)
+	assert.Zero(t, count)
+}
+
+func TestLoadRedactionNames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "redact-names.txt")
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(path, []byte("# Family\nAnna Smith\n\n  Oliver  \n"), 0o644))
+
+	names, err := content.LoadRedactionNames(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Anna Smith", "Oliver"}, names)
+
+	names, err = content.LoadRedactionNames(filepath.Join(t.TempDir(), "missing.txt"))
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+// echoProvider records the last request and answers with canned output.
+type echoProvider struct {
+	request  content.ChatRequest
+	response *content.ChatResponse
+}
+
+func (p *echoProvider) Name() string         { return "Echo API" }
+func (p *echoProvider) DefaultModel() string { return "echo" }
+func (p *echoProvider) Validate() error      { return nil }
+
+func (p *echoProvider) Chat(_ context.Context, req content.ChatRequest) (*content.ChatResponse, error) {
+	p.request = req
+	return p.response, nil
+}
+
+func (p *echoProvider) StreamChat(
+	_ context.Context,
+	req content.ChatRequest,
+	onText func(string),
+) (*content.ChatResponse, error) {
+	p.request = req
+	if onText != nil {
+		onText(p.response.Text)
+	}
+	return p.response, nil
+}
+
+func TestRedactingProvider(t *testing.T) {
+	echo := &echoProvider{response: &content.ChatResponse{
+		Text: "Dinner with [NAME_1].",
+		ToolCall: &content.ToolCall{
+			Name:  "save_copy_edit",
+			Input: json.RawMessage(`{"markdown": "Call [NAME_1] at [PHONE_1]", "changes": ["Kept [NAME_1]"]}`),
+		},
+	}}
+	provider := content.NewRedactingProvider(echo, content.NewRedactor([]string{"Anna \"Nan\" Smith"}))
+
+	req := content.ChatRequest{
+		System:   []string{"You are a copy editor."},
+		Messages: []content.ChatMessage{{Text: "Dinner with Anna \"Nan\" Smith, 206-555-0134."}},
+	}
+
+	var streamed string
+	resp, err := provider.StreamChat(context.Background(), req, func(text string) { streamed = text })
+	require.NoError(t, err)
+
+	// Only placeholders reach the API, and the model is told to keep them
+	assert.Equal(t, "Dinner with [NAME_1], [PHONE_1].", echo.request.Messages[0].Text)
+	assert.Len(t, echo.request.System, 2)
+	assert.Contains(t, echo.request.System[1], "placeholders")
+	assert.Equal(t, "Dinner with Anna \"Nan\" Smith, 206-555-0134.", req.Messages[0].Text)
+
+	assert.Equal(t, "Dinner with Anna \"Nan\" Smith.", streamed)
+	assert.Equal(t, "Dinner with Anna \"Nan\" Smith.", resp.Text)
+
+	// Values are restored inside the tool call's JSON, escaped
+	var input struct {
+		Markdown string   `json:"markdown"`
+		Changes  []string `json:"changes"`
+	}
+	require.NoError(t, json.Unmarshal(resp.ToolCall.Input, &input))
+	assert.Equal(t, "Call Anna \"Nan\" Smith at 206-555-0134", input.Markdown)
+	assert.Equal(t, []string{"Kept Anna \"Nan\" Smith"}, input.Changes)
+}
@@ -0,0 +1,200 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/openai/openai-go"
+)
+
+// statusOverloaded is Anthropic's non-standard "overloaded" status code.
+const statusOverloaded = 529
+
+// RetryPolicy retries transient API failures with exponential backoff and jitter.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles per attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. A Retry-After longer than this gives up instead.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used by Transcriber and Writer.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Second,
+		MaxDelay:    time.Minute,
+	}
+}
+
+// RetryEvent describes an upcoming retry.
+type RetryEvent struct {
+	// Attempt is the attempt about to be made (2 for the first retry).
+	Attempt     int
+	MaxAttempts int
+	Delay       time.Duration
+	Err         error
+}
+
+// String renders the event for status lines, e.g. "retrying (2/5) in 4s…".
+func (e RetryEvent) String() string {
+	return fmt.Sprintf("retrying (%d/%d) in %s…", e.Attempt, e.MaxAttempts, e.Delay.Round(time.Second))
+}
+
+type retryNotifierKey struct{}
+
+// WithRetryNotifier returns a context whose requests report retries to notify.
+// notify is called from the request goroutine before each backoff sleep.
+func WithRetryNotifier(ctx context.Context, notify func(RetryEvent)) context.Context {
+	return context.WithValue(ctx, retryNotifierKey{}, notify)
+}
+
+func notifyRetry(ctx context.Context, event RetryEvent) {
+	if notify, ok := ctx.Value(retryNotifierKey{}).(func(RetryEvent)); ok {
+		notify(event)
+	}
+}
+
+// Do calls fn until it succeeds, returns a non-retryable error, or the policy
+// runs out of attempts. Context cancellation stops retrying immediately.
+func (p RetryPolicy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	maxAttempts := max(p.MaxAttempts, 1)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+		if err == nil || attempt >= maxAttempts || !IsRetryable(err) {
+			return err
+		}
+
+		delay, ok := p.delay(attempt, err)
+		if !ok {
+			return err
+		}
+
+		notifyRetry(ctx, RetryEvent{
+			Attempt:     attempt + 1,
+			MaxAttempts: maxAttempts,
+			Delay:       delay,
+			Err:         err,
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry aborted: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns how long to wait after the given failed attempt.
+// Returns ok=false when the server asks for a longer wait than MaxDelay.
+func (p RetryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	if retryAfter, ok := retryAfter(err); ok {
+		return retryAfter, retryAfter <= p.MaxDelay
+	}
+
+	backoff := p.BaseDelay << (attempt - 1)
+	if backoff <= 0 || backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+
+	// Equal jitter: keep half the backoff, randomize the other half
+	half := backoff / 2
+	if half <= 0 {
+		return backoff, true
+	}
+
+	//nolint:gosec // jitter does not need a cryptographic source
+	return half + rand.N(half), true
+}
+
+// IsRetryable reports whether err is a transient API failure:
+// rate limiting, overload, timeouts, or a server error.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	status, _, ok := apiErrorDetails(err)
+	if !ok {
+		// An unknown host will not resolve on retry
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) {
+			return dnsErr.IsTimeout || dnsErr.IsTemporary
+		}
+
+		// Connection resets and transport timeouts surface as net errors
+		var netErr net.Error
+		return errors.As(err, &netErr)
+	}
+
+	switch {
+	case status == http.StatusRequestTimeout,
+		status == http.StatusConflict,
+		status == http.StatusTooManyRequests,
+		status == statusOverloaded,
+		status >= http.StatusInternalServerError:
+		return true
+	}
+
+	return false
+}
+
+// retryAfter extracts the server-requested wait from Retry-After headers.
+func retryAfter(err error) (time.Duration, bool) {
+	_, header, ok := apiErrorDetails(err)
+	if !ok || header == nil {
+		return 0, false
+	}
+
+	if ms, err := strconv.ParseFloat(header.Get("Retry-After-Ms"), 64); err == nil && ms >= 0 {
+		return time.Duration(ms * float64(time.Millisecond)), true
+	}
+
+	value := header.Get("Retry-After")
+	if secs, err := strconv.ParseFloat(value, 64); err == nil && secs >= 0 {
+		return time.Duration(secs * float64(time.Second)), true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+// apiErrorDetails unwraps the status code and response headers of an
+// Anthropic or OpenAI API error.
+func apiErrorDetails(err error) (int, http.Header, bool) {
+	var anthropicErr *anthropic.Error
+	if errors.As(err, &anthropicErr) {
+		return anthropicErr.StatusCode, responseHeader(anthropicErr.Response), true
+	}
+
+	var openaiErr *openai.Error
+	if errors.As(err, &openaiErr) {
+		return openaiErr.StatusCode, responseHeader(openaiErr.Response), true
+	}
+
+	return 0, nil, false
+}
+
+func responseHeader(resp *http.Response) http.Header {
+	if resp == nil {
+		return nil
+	}
+
+	return resp.Header
+}
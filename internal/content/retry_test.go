@@ -0,0 +1,131 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 50 * time.Millisecond}
+}
+
+func apiError(status int, header http.Header) error {
+	return &anthropic.Error{StatusCode: status, Response: &http.Response{StatusCode: status, Header: header}}
+}
+
+func TestRetryPolicy_Do_RetriesTransientErrors(t *testing.T) {
+	var events []RetryEvent
+	ctx := WithRetryNotifier(context.Background(), func(e RetryEvent) { events = append(events, e) })
+
+	calls := 0
+	err := testRetryPolicy().Do(ctx, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return apiError(statusOverloaded, nil)
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	require.Len(t, events, 2)
+	assert.Equal(t, 2, events[0].Attempt)
+	assert.Equal(t, 3, events[1].Attempt)
+	assert.Equal(t, 3, events[1].MaxAttempts)
+}
+
+func TestRetryPolicy_Do_GivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	err := testRetryPolicy().Do(context.Background(), func(context.Context) error {
+		calls++
+		return &openai.Error{StatusCode: http.StatusServiceUnavailable}
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryPolicy_Do_NonRetryable(t *testing.T) {
+	calls := 0
+	err := testRetryPolicy().Do(context.Background(), func(context.Context) error {
+		calls++
+		return apiError(http.StatusBadRequest, nil)
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryPolicy_Do_HonorsRetryAfter(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After-Ms", "20")
+
+	var events []RetryEvent
+	ctx := WithRetryNotifier(context.Background(), func(e RetryEvent) { events = append(events, e) })
+
+	calls := 0
+	err := testRetryPolicy().Do(ctx, func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return apiError(http.StatusTooManyRequests, header)
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, 20*time.Millisecond, events[0].Delay)
+}
+
+func TestRetryPolicy_Do_RetryAfterBeyondMaxDelay(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "120")
+
+	calls := 0
+	err := testRetryPolicy().Do(context.Background(), func(context.Context) error {
+		calls++
+		return apiError(http.StatusTooManyRequests, header)
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryPolicy_Do_CancelledDuringBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = WithRetryNotifier(ctx, func(RetryEvent) { cancel() })
+
+	err := policy.Do(ctx, func(context.Context) error {
+		return apiError(http.StatusInternalServerError, nil)
+	})
+
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(apiError(http.StatusTooManyRequests, nil)))
+	assert.True(t, IsRetryable(apiError(statusOverloaded, nil)))
+	assert.True(t, IsRetryable(&openai.Error{StatusCode: http.StatusBadGateway}))
+	assert.False(t, IsRetryable(apiError(http.StatusUnauthorized, nil)))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.False(t, IsRetryable(errors.New("boom")))
+	assert.False(t, IsRetryable(&net.DNSError{Err: "no such host", IsNotFound: true}))
+	assert.True(t, IsRetryable(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+}
+
+func TestRetryEvent_String(t *testing.T) {
+	event := RetryEvent{Attempt: 2, MaxAttempts: 5, Delay: 4 * time.Second}
+
+	assert.Equal(t, "retrying (2/5) in 4s…", event.String())
+}
@@ -0,0 +1,55 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ReviseCopyEdit applies the author's feedback to a copy-edited post and
+// returns the revised post with the changes made in this revision. Tags are
+// split against tags as in GenerateCopyEdit.
+func (w *Writer) ReviseCopyEdit(
+	ctx context.Context,
+	markdown, feedback string,
+	mode Mode,
+	tags *TagIndex,
+) (*CopyEditResult, error) {
+	if err := w.provider.Validate(); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(feedback) == "" {
+		return nil, errors.New("revision feedback is empty")
+	}
+
+	modeConfig, err := w.config.Modes.Lookup(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	tags = w.requiredTags(tags)
+
+	systemPrompt, err := w.renderPrompt(PromptRevise, modeConfig, "", tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// The post already has the blog's style, so exemplars are not resent
+	system := []string{systemPrompt}
+
+	// Footnotes of the post being revised must survive the revision
+	validate := func(revised string) []string {
+		return ValidateCopyEdit(markdown, revised, modeConfig)
+	}
+
+	ctx = withUsageLabel(ctx, UsagePhaseRevise, mode)
+	related := w.config.Posts.Related(markdown, MaxRelatedPosts)
+
+	return w.requestCopyEdit(ctx, system, revisionRequest(markdown, feedback), related, tags, validate)
+}
+
+// revisionRequest formats the current post and feedback as one user message.
+func revisionRequest(markdown, feedback string) string {
+	return "<post>\n" + markdown + "\n</post>\n\n<feedback>\n" + strings.TrimSpace(feedback) + "\n</feedback>"
+}
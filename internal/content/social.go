@@ -0,0 +1,205 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SocialPlatform is a site the author shares posts on.
+type SocialPlatform struct {
+	// Name is the key of the account in hugo.yaml's params.author, e.g. "linkedin".
+	Name  string
+	Label string
+	// MaxLength is the longest post the site accepts, in characters.
+	MaxLength int
+}
+
+// socialPlatforms lists the sites social posts can be written for, in the
+// order they are shown.
+var socialPlatforms = []SocialPlatform{
+	{Name: "linkedin", Label: "LinkedIn", MaxLength: 3000},
+	{Name: "threads", Label: "Threads", MaxLength: 500},
+	{Name: "bluesky", Label: "Bluesky", MaxLength: 300},
+	{Name: "mastodon", Label: "Mastodon", MaxLength: 500},
+	{Name: "x", Label: "X", MaxLength: 280},
+}
+
+// DefaultSocialPlatforms returns LinkedIn and Threads, where the blog is
+// shared.
+func DefaultSocialPlatforms() []SocialPlatform {
+	return slices.Clone(socialPlatforms[:2])
+}
+
+// hugoConfig holds the hugo.yaml fields the voice CLI cares about.
+type hugoConfig struct {
+	Params struct {
+		Author map[string]any `yaml:"author"`
+	} `yaml:"params"`
+}
+
+// LoadSocialPlatforms returns the known sites with an account under
+// params.author in the Hugo config at path. A missing file yields
+// DefaultSocialPlatforms.
+func LoadSocialPlatforms(path string) ([]SocialPlatform, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultSocialPlatforms(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Hugo config %s: %w", path, err)
+	}
+
+	var config hugoConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse Hugo config %s: %w", path, err)
+	}
+
+	var platforms []SocialPlatform
+	for _, platform := range socialPlatforms {
+		if account, ok := config.Params.Author[platform.Name]; ok && account != nil && account != "" {
+			platforms = append(platforms, platform)
+		}
+	}
+
+	return platforms, nil
+}
+
+// SocialPost is a post sharing the blog post on one site.
+type SocialPost struct {
+	Platform SocialPlatform
+	Text     string
+}
+
+// Length is the post's length in characters, as the sites count them.
+func (p SocialPost) Length() int {
+	return utf8.RuneCountInString(p.Text)
+}
+
+// TooLong reports whether the site would reject the post.
+func (p SocialPost) TooLong() bool {
+	return p.Length() > p.Platform.MaxLength
+}
+
+// socialTool returns the tool definition for social post structured output,
+// with one property per platform.
+func socialTool(platforms []SocialPlatform) *Tool {
+	properties := map[string]any{}
+	required := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		properties[platform.Name] = map[string]any{
+			"type": "string",
+			"description": fmt.Sprintf("Post for %s, at most %d characters",
+				platform.Label, platform.MaxLength),
+		}
+		required = append(required, platform.Name)
+	}
+
+	return &Tool{
+		Name:        "save_social_posts",
+		Description: "Save a post sharing the blog post for each social site",
+		Properties:  properties,
+		Required:    required,
+	}
+}
+
+// GenerateSocialPosts writes a post sharing the markdown on each platform,
+// in the platforms' order.
+func (w *Writer) GenerateSocialPosts(
+	ctx context.Context,
+	markdown string,
+	platforms []SocialPlatform,
+) ([]SocialPost, error) {
+	if len(platforms) == 0 {
+		return nil, nil
+	}
+
+	if err := w.provider.Validate(); err != nil {
+		return nil, err
+	}
+
+	systemPrompt, err := w.renderPrompt(PromptSocial, ModeConfig{}, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req := ChatRequest{
+		System:   []string{systemPrompt},
+		Messages: []ChatMessage{{Text: socialPrompt(markdown, platforms)}},
+		Params:   w.config.CopyEdit,
+		Tool:     socialTool(platforms),
+	}
+
+	resp, err := w.chat(withUsageLabel(ctx, UsagePhaseSocial, ""), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate social posts via %s: %w", w.provider.Name(), err)
+	}
+
+	if resp.Truncated {
+		return nil, truncationError("the social posts", w.config.CopyEdit.MaxTokens, "--copy-edit-max-tokens")
+	}
+
+	return parseSocialToolCall(resp, platforms)
+}
+
+// socialPrompt is the post followed by the sites to write for and their
+// limits.
+func socialPrompt(markdown string, platforms []SocialPlatform) string {
+	var sb strings.Builder
+	sb.WriteString(markdown)
+	sb.WriteString("\n\n<platforms>\n")
+	for _, platform := range platforms {
+		fmt.Fprintf(&sb, "- %s (%s): at most %d characters\n", platform.Name, platform.Label, platform.MaxLength)
+	}
+	sb.WriteString("</platforms>")
+
+	return sb.String()
+}
+
+// parseSocialToolCall extracts the posts from the response's
+// save_social_posts call.
+func parseSocialToolCall(resp *ChatResponse, platforms []SocialPlatform) ([]SocialPost, error) {
+	if resp.ToolCall == nil {
+		return nil, errors.New("no save_social_posts call found in response")
+	}
+
+	var input map[string]string
+	if err := json.Unmarshal(resp.ToolCall.Input, &input); err != nil {
+		return nil, fmt.Errorf("failed to parse social posts tool input: %w", err)
+	}
+
+	posts := make([]SocialPost, 0, len(platforms))
+	for _, platform := range platforms {
+		text := strings.TrimSpace(input[platform.Name])
+		if text == "" {
+			return nil, fmt.Errorf("no post for %s in save_social_posts call", platform.Label)
+		}
+		posts = append(posts, SocialPost{Platform: platform, Text: text})
+	}
+
+	return posts, nil
+}
+
+// SocialSidecarPath returns where the social posts for the post at postPath
+// are kept, e.g. 2025-11-voice-cli.social.md next to 2025-11-voice-cli.md.
+// hugo.yaml ignores these files.
+func SocialSidecarPath(postPath string) string {
+	return strings.TrimSuffix(postPath, ".md") + ".social.md"
+}
+
+// FormatSocialPosts renders the posts as markdown with a section per site.
+func FormatSocialPosts(posts []SocialPost) string {
+	sections := make([]string, 0, len(posts))
+	for _, post := range posts {
+		sections = append(sections, "## "+post.Platform.Label+"\n\n"+post.Text+"\n")
+	}
+
+	return strings.Join(sections, "\n")
+}
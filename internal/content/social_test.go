@@ -0,0 +1,69 @@
+package content_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSocialPlatforms(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hugo.yaml")
+	config := `title: "Memos"
+params:
+  author:
+    name: James McKernan
+    threads: "@jamcmdr"
+    github: alkime
+    linkedin: jamesmckernan
+    x: ""
+`
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o644)) //nolint:gosec // Test file
+
+	platforms, err := content.LoadSocialPlatforms(path)
+	require.NoError(t, err)
+
+	// Known sites with an account, in display order
+	require.Len(t, platforms, 2)
+	assert.Equal(t, "linkedin", platforms[0].Name)
+	assert.Equal(t, 3000, platforms[0].MaxLength)
+	assert.Equal(t, "threads", platforms[1].Name)
+	assert.Equal(t, 500, platforms[1].MaxLength)
+}
+
+func TestLoadSocialPlatforms_MissingFile(t *testing.T) {
+	platforms, err := content.LoadSocialPlatforms(filepath.Join(t.TempDir(), "hugo.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, content.DefaultSocialPlatforms(), platforms)
+}
+
+func TestFormatSocialPosts(t *testing.T) {
+	platforms := content.DefaultSocialPlatforms()
+	posts := []content.SocialPost{
+		{Platform: platforms[0], Text: "I built a voice CLI.\n\nHere's what I learned."},
+		{Platform: platforms[1], Text: "Talking my way to a blog post."},
+	}
+
+	assert.Equal(t,
+		"## LinkedIn\n\nI built a voice CLI.\n\nHere's what I learned.\n\n"+
+			"## Threads\n\nTalking my way to a blog post.\n",
+		content.FormatSocialPosts(posts))
+	assert.Equal(t, "content/posts/2025-11-voice-cli.social.md",
+		content.SocialSidecarPath("content/posts/2025-11-voice-cli.md"))
+}
+
+func TestSocialPost_TooLong(t *testing.T) {
+	threads := content.DefaultSocialPlatforms()[1]
+
+	// Lengths count characters, not bytes
+	fits := content.SocialPost{Platform: threads, Text: strings.Repeat("a", 500)}
+	assert.Equal(t, 500, fits.Length())
+	assert.False(t, fits.TooLong())
+
+	tooLong := content.SocialPost{Platform: threads, Text: fits.Text + "é"}
+	assert.True(t, tooLong.TooLong())
+}
@@ -10,40 +10,103 @@ import (
 	"github.com/openai/openai-go/option"
 )
 
+// TranscriptionHints steer Whisper towards the right language and spellings.
+type TranscriptionHints struct {
+	// Language is an ISO-639-1 code (e.g. "en", "de"). Empty auto-detects.
+	Language string
+	// Prompt lists vocabulary that should be spelled as written.
+	Prompt string
+}
+
 // Transcriber handles Whisper API transcription requests.
 type Transcriber struct {
 	apiKey string
+	hints  TranscriptionHints
+	retry  RetryPolicy
+	usage  UsageRecorder
 }
 
-// NewTranscriber creates a new transcription client.
-func NewTranscriber(apiKey string) *Transcriber {
+// NewTranscriber creates a new transcription client. usage, when non-nil,
+// records the audio duration of every request.
+func NewTranscriber(apiKey string, hints TranscriptionHints, usage UsageRecorder) *Transcriber {
 	return &Transcriber{
 		apiKey: apiKey,
+		hints:  hints,
+		retry:  DefaultRetryPolicy(),
+		usage:  usage,
+	}
+}
+
+// CacheKey identifies a transcription of the audio with the given hash by
+// this transcriber's backend, model and language.
+func (t *Transcriber) CacheKey(audioHash string) TranscriptCacheKey {
+	return TranscriptCacheKey{
+		AudioHash: audioHash,
+		Backend:   "openai",
+		Model:     openai.AudioModelWhisper1,
+		Language:  t.hints.Language,
 	}
 }
 
 // TranscribeFile transcribes an audio file using Whisper API.
-func (t *Transcriber) TranscribeFile(audioFile io.Reader) (string, error) {
+// The request is aborted when ctx is cancelled or its deadline passes.
+// Transient failures are retried when audioFile can be rewound (io.Seeker).
+func (t *Transcriber) TranscribeFile(ctx context.Context, audioFile io.Reader) (string, error) {
 	// Validate API key
 	if t.apiKey == "" {
 		return "", errors.New("API key required: set OPENAI_API_KEY or use --api-key")
 	}
 
-	// Create OpenAI client
-	client := openai.NewClient(option.WithAPIKey(t.apiKey))
+	// Create OpenAI client; retries are handled by our own policy
+	client := openai.NewClient(option.WithAPIKey(t.apiKey), option.WithMaxRetries(0))
 
 	// Create transcription request
 	params := openai.AudioTranscriptionNewParams{
 		File:  audioFile,
 		Model: openai.AudioModelWhisper1,
 	}
+	if t.hints.Language != "" {
+		params.Language = openai.String(t.hints.Language)
+	}
+	if t.hints.Prompt != "" {
+		params.Prompt = openai.String(t.hints.Prompt)
+	}
+
+	// A reader that cannot be rewound can only be uploaded once
+	policy := t.retry
+	seeker, seekable := audioFile.(io.Seeker)
+	if !seekable {
+		policy.MaxAttempts = 1
+	}
 
 	// Call Whisper API
-	ctx := context.Background()
-	resp, err := client.Audio.Transcriptions.New(ctx, params)
+	var text string
+	err := policy.Do(ctx, func(ctx context.Context) error {
+		if seekable {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return fmt.Errorf("failed to rewind audio file: %w", err)
+			}
+		}
+
+		resp, err := client.Audio.Transcriptions.New(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to create transcription via Whisper API: %w", err)
+		}
+		text = resp.Text
+
+		if t.usage != nil {
+			t.usage.Record(UsageRecord{
+				Phase:        UsagePhaseTranscribe,
+				Model:        params.Model,
+				AudioSeconds: resp.Usage.Seconds,
+			})
+		}
+
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to create transcription via Whisper API: %w", err)
+		return "", err
 	}
 
-	return resp.Text, nil
+	return text, nil
 }
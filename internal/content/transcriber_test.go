@@ -1,6 +1,7 @@
 package content
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -10,24 +11,24 @@ import (
 func TestNewTranscriber(t *testing.T) {
 	apiKey := "test-api-key"
 
-	transcriber := NewTranscriber(apiKey)
+	transcriber := NewTranscriber(apiKey, TranscriptionHints{}, nil)
 
 	assert.NotNil(t, transcriber)
 	assert.Equal(t, apiKey, transcriber.apiKey)
 }
 
 func TestNewTranscriber_EmptyAPIKey(t *testing.T) {
-	transcriber := NewTranscriber("")
+	transcriber := NewTranscriber("", TranscriptionHints{}, nil)
 
 	assert.NotNil(t, transcriber)
 	assert.Equal(t, "", transcriber.apiKey)
 }
 
 func TestTranscriber_TranscribeFile_MissingAPIKey(t *testing.T) {
-	transcriber := NewTranscriber("")
+	transcriber := NewTranscriber("", TranscriptionHints{}, nil)
 	reader := strings.NewReader("fake audio data")
 
-	text, err := transcriber.TranscribeFile(reader)
+	text, err := transcriber.TranscribeFile(context.Background(), reader)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "API key")
@@ -35,10 +36,10 @@ func TestTranscriber_TranscribeFile_MissingAPIKey(t *testing.T) {
 }
 
 func TestTranscriber_TranscribeFile_EmptyFile(t *testing.T) {
-	transcriber := NewTranscriber("test-key")
+	transcriber := NewTranscriber("test-key", TranscriptionHints{}, nil)
 	reader := strings.NewReader("")
 
-	text, err := transcriber.TranscribeFile(reader)
+	text, err := transcriber.TranscribeFile(context.Background(), reader)
 
 	// Empty file will likely cause API error, but we're just testing
 	// that the method handles it without panicking
@@ -48,3 +49,23 @@ func TestTranscriber_TranscribeFile_EmptyFile(t *testing.T) {
 	_ = err
 }
 
+func TestNewTranscriber_Hints(t *testing.T) {
+	hints := TranscriptionHints{Language: "de", Prompt: "Glossary: Bubbletea, Fly.io."}
+
+	transcriber := NewTranscriber("test-key", hints, nil)
+
+	assert.Equal(t, hints, transcriber.hints)
+}
+
+func TestTranscriber_TranscribeFile_CancelledContext(t *testing.T) {
+	transcriber := NewTranscriber("test-key", TranscriptionHints{}, nil)
+	reader := strings.NewReader("fake audio data")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	text, err := transcriber.TranscribeFile(ctx, reader)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, text)
+}
@@ -0,0 +1,268 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TranscriptCacheKey identifies a transcription: the same audio sent to the
+// same backend, model and language yields a reusable transcript.
+type TranscriptCacheKey struct {
+	// AudioHash is the hex SHA-256 of the audio file contents.
+	AudioHash string `json:"audio_hash"`
+	Backend   string `json:"backend"`
+	Model     string `json:"model"`
+	// Language is the requested language; empty when auto-detected.
+	Language string `json:"language,omitempty"`
+}
+
+// id returns the file name stem for the key.
+func (k TranscriptCacheKey) id() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{k.AudioHash, k.Backend, k.Model, k.Language}, "\x00")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// transcriptCacheEntry is the on-disk form of a cached transcript.
+type transcriptCacheEntry struct {
+	Key       TranscriptCacheKey `json:"key"`
+	Text      string             `json:"text"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// TranscriptCache stores transcripts as JSON files in a directory, one per key.
+// An entry's modification time records when it was last used.
+type TranscriptCache struct {
+	dir string
+}
+
+// NewTranscriptCache creates a cache rooted at dir. The directory is created
+// on the first Put.
+func NewTranscriptCache(dir string) *TranscriptCache {
+	return &TranscriptCache{dir: dir}
+}
+
+func (c *TranscriptCache) path(key TranscriptCacheKey) string {
+	return filepath.Join(c.dir, key.id()+".json")
+}
+
+// Get returns the cached transcript for key, if any. Unreadable entries are
+// treated as misses.
+func (c *TranscriptCache) Get(key TranscriptCacheKey) (string, bool) {
+	path := c.path(key)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Warn("failed to read transcript cache entry", "path", path, "error", err)
+		}
+
+		return "", false
+	}
+
+	var entry transcriptCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		slog.Warn("ignoring corrupt transcript cache entry", "path", path, "error", err)
+
+		return "", false
+	}
+
+	// Mark as recently used so prune keeps it
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		slog.Debug("failed to touch transcript cache entry", "path", path, "error", err)
+	}
+
+	return entry.Text, true
+}
+
+// Put stores text under key, replacing any existing entry.
+func (c *TranscriptCache) Put(key TranscriptCacheKey, text string) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create transcript cache directory %s: %w", c.dir, err)
+	}
+
+	data, err := json.MarshalIndent(transcriptCacheEntry{
+		Key:       key,
+		Text:      text,
+		CreatedAt: time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode transcript cache entry: %w", err)
+	}
+
+	// Write then rename so a concurrent reader never sees a partial entry
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create transcript cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write transcript cache entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write transcript cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("failed to store transcript cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// TranscriptCacheStats summarizes the cache contents.
+type TranscriptCacheStats struct {
+	Entries int
+	Bytes   int64
+	// Oldest and Newest are last-use times; zero when the cache is empty.
+	Oldest time.Time
+	Newest time.Time
+}
+
+// Stats reports the number and size of cached transcripts.
+func (c *TranscriptCache) Stats() (TranscriptCacheStats, error) {
+	var stats TranscriptCacheStats
+
+	err := c.walk(func(_ string, info fs.FileInfo) error {
+		stats.Entries++
+		stats.Bytes += info.Size()
+
+		if stats.Oldest.IsZero() || info.ModTime().Before(stats.Oldest) {
+			stats.Oldest = info.ModTime()
+		}
+
+		if info.ModTime().After(stats.Newest) {
+			stats.Newest = info.ModTime()
+		}
+
+		return nil
+	})
+
+	return stats, err
+}
+
+// Prune removes entries not used within olderThan and returns how many were
+// removed. A zero olderThan removes every entry.
+func (c *TranscriptCache) Prune(olderThan time.Duration) (int, error) {
+	cutoff := time.Now().Add(-olderThan)
+	removed := 0
+
+	err := c.walk(func(path string, info fs.FileInfo) error {
+		if olderThan > 0 && info.ModTime().After(cutoff) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove transcript cache entry %s: %w", path, err)
+		}
+		removed++
+
+		return nil
+	})
+
+	return removed, err
+}
+
+// walk calls fn for each cache entry. A missing directory has no entries.
+func (c *TranscriptCache) walk(fn func(path string, info fs.FileInfo) error) error {
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list transcript cache: %w", err)
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat transcript cache entry %s: %w", path, err)
+		}
+
+		if err := fn(path, info); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CachedTranscriber consults a TranscriptCache before sending audio to Whisper
+// and stores new transcripts in it.
+type CachedTranscriber struct {
+	transcriber *Transcriber
+	cache       *TranscriptCache
+}
+
+// NewCachedTranscriber wraps transcriber with cache.
+func NewCachedTranscriber(transcriber *Transcriber, cache *TranscriptCache) *CachedTranscriber {
+	return &CachedTranscriber{
+		transcriber: transcriber,
+		cache:       cache,
+	}
+}
+
+// TranscribeFile returns the cached transcript for the audio when there is
+// one, and otherwise transcribes it and caches the result.
+func (ct *CachedTranscriber) TranscribeFile(ctx context.Context, audioFile io.Reader) (string, error) {
+	audio, hash, err := hashAudio(audioFile)
+	if err != nil {
+		return "", err
+	}
+
+	key := ct.transcriber.CacheKey(hash)
+	if text, ok := ct.cache.Get(key); ok {
+		slog.Info("Using cached transcript", "audio", hash[:12])
+		return text, nil
+	}
+
+	text, err := ct.transcriber.TranscribeFile(ctx, audio)
+	if err != nil {
+		return "", err
+	}
+
+	// A cache write failure only costs a future re-upload
+	if err := ct.cache.Put(key, text); err != nil {
+		slog.Warn("failed to cache transcript", "error", err)
+	}
+
+	return text, nil
+}
+
+// hashAudio returns the hex SHA-256 of r and a reader positioned at the start
+// of the same audio. Seekable readers are rewound; others are buffered.
+func hashAudio(r io.Reader) (io.Reader, string, error) {
+	h := sha256.New()
+
+	if seeker, ok := r.(io.ReadSeeker); ok {
+		if _, err := io.Copy(h, seeker); err != nil {
+			return nil, "", fmt.Errorf("failed to hash audio: %w", err)
+		}
+
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return nil, "", fmt.Errorf("failed to rewind audio after hashing: %w", err)
+		}
+
+		return seeker, hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	data, err := io.ReadAll(io.TeeReader(r, h))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	return bytes.NewReader(data), hex.EncodeToString(h.Sum(nil)), nil
+}
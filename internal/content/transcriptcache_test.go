@@ -0,0 +1,127 @@
+package content
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptCache_PutGet(t *testing.T) {
+	cache := NewTranscriptCache(filepath.Join(t.TempDir(), "transcripts"))
+	key := TranscriptCacheKey{AudioHash: "abc", Backend: "openai", Model: "whisper-1"}
+
+	_, ok := cache.Get(key)
+	assert.False(t, ok, "Empty cache should miss")
+
+	require.NoError(t, cache.Put(key, "hello world"))
+
+	text, ok := cache.Get(key)
+	assert.True(t, ok)
+	assert.Equal(t, "hello world", text)
+
+	// Any other language is a different transcription
+	key.Language = "de"
+	_, ok = cache.Get(key)
+	assert.False(t, ok, "Language should be part of the key")
+}
+
+func TestTranscriptCache_StatsAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewTranscriptCache(dir)
+
+	oldKey := TranscriptCacheKey{AudioHash: "old", Backend: "openai", Model: "whisper-1"}
+	newKey := TranscriptCacheKey{AudioHash: "new", Backend: "openai", Model: "whisper-1"}
+	require.NoError(t, cache.Put(oldKey, "old transcript"))
+	require.NoError(t, cache.Put(newKey, "new transcript"))
+
+	lastUsed := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(cache.path(oldKey), lastUsed, lastUsed))
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Entries)
+	assert.Positive(t, stats.Bytes)
+	assert.True(t, stats.Oldest.Before(stats.Newest))
+
+	removed, err := cache.Prune(24 * time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	_, ok := cache.Get(newKey)
+	assert.True(t, ok, "Recently used entry should survive prune")
+
+	removed, err = cache.Prune(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	stats, err = cache.Stats()
+	require.NoError(t, err)
+	assert.Zero(t, stats.Entries)
+}
+
+func TestTranscriptCache_StatsMissingDir(t *testing.T) {
+	cache := NewTranscriptCache(filepath.Join(t.TempDir(), "missing"))
+
+	stats, err := cache.Stats()
+
+	require.NoError(t, err)
+	assert.Zero(t, stats.Entries)
+}
+
+func TestCachedTranscriber_Hit(t *testing.T) {
+	cache := NewTranscriptCache(t.TempDir())
+	// No API key: a cache miss would fail
+	transcriber := NewTranscriber("", TranscriptionHints{Language: "en"}, nil)
+
+	audio := "fake audio bytes"
+	_, hash, err := hashAudio(strings.NewReader(audio))
+	require.NoError(t, err)
+	require.NoError(t, cache.Put(transcriber.CacheKey(hash), "cached transcript"))
+
+	text, err := NewCachedTranscriber(transcriber, cache).
+		TranscribeFile(context.Background(), strings.NewReader(audio))
+
+	require.NoError(t, err)
+	assert.Equal(t, "cached transcript", text)
+}
+
+func TestCachedTranscriber_MissCallsTranscriber(t *testing.T) {
+	cache := NewTranscriptCache(t.TempDir())
+	transcriber := NewTranscriber("", TranscriptionHints{}, nil)
+
+	_, err := NewCachedTranscriber(transcriber, cache).
+		TranscribeFile(context.Background(), strings.NewReader("other audio"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API key required")
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Zero(t, stats.Entries, "Failures should not be cached")
+}
+
+func TestHashAudio_RewindsSeeker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audio.mp3")
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(path, []byte("audio"), 0o644))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	r, hash, err := hashAudio(file)
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+	assert.Same(t, file, r, "Files should be passed through so uploads keep their name")
+
+	data := make([]byte, 5)
+	_, err = r.Read(data)
+	require.NoError(t, err)
+	assert.Equal(t, "audio", string(data))
+}
@@ -0,0 +1,89 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// translationMaxTokens is the smallest token limit used for translations.
+const translationMaxTokens = 8192
+
+// TranslationResult wraps the output from TranslateTranscript.
+type TranslationResult struct {
+	// SourceLanguage is the English name of the spoken language, e.g. "German".
+	SourceLanguage string `json:"source_language"`
+	// Text is the English transcript.
+	Text string `json:"text"`
+}
+
+// translationTool returns the tool definition for translation structured output.
+func translationTool() *Tool {
+	return &Tool{
+		Name:        "save_translation",
+		Description: "Save the English translation of the transcript and its source language",
+		Properties: map[string]any{
+			"source_language": map[string]any{
+				"type":        "string",
+				"description": "English name of the language the transcript was spoken in",
+			},
+			"text": map[string]any{
+				"type":        "string",
+				"description": "The transcript translated into English",
+			},
+		},
+		Required: []string{"source_language", "text"},
+	}
+}
+
+// TranslateTranscript translates a transcript into English and reports
+// which language it was spoken in.
+func (w *Writer) TranslateTranscript(ctx context.Context, transcript string) (*TranslationResult, error) {
+	if err := w.provider.Validate(); err != nil {
+		return nil, err
+	}
+
+	systemPrompt, err := w.renderPrompt(PromptTranslate, ModeConfig{}, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Translation is part of drafting, but the output is about as long as the
+	// transcript, so it needs more room than a draft
+	generation := w.config.FirstDraft
+	generation.MaxTokens = max(generation.MaxTokens, translationMaxTokens)
+
+	req := ChatRequest{
+		System:   []string{systemPrompt},
+		Messages: []ChatMessage{{Text: transcript}},
+		Params:   generation,
+		Tool:     translationTool(),
+	}
+
+	resp, err := w.chat(withUsageLabel(ctx, UsagePhaseTranslate, ""), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate transcript via %s: %w", w.provider.Name(), err)
+	}
+
+	if resp.Truncated {
+		return nil, truncationError("the translation", generation.MaxTokens, "--draft-max-tokens")
+	}
+
+	return parseTranslationToolCall(resp)
+}
+
+// parseTranslationToolCall extracts TranslationResult from the response's
+// save_translation call.
+func parseTranslationToolCall(resp *ChatResponse) (*TranslationResult, error) {
+	if resp.ToolCall == nil {
+		return nil, errors.New("no save_translation call found in response")
+	}
+
+	var result TranslationResult
+	if err := json.Unmarshal(resp.ToolCall.Input, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse translation tool input: %w", err)
+	}
+
+	return &result, nil
+}
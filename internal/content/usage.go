@@ -0,0 +1,390 @@
+package content
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phases recorded in the usage ledger besides PhaseFirstDraft and
+// PhaseCopyEdit.
+const (
+	UsagePhaseTranscribe = "transcribe"
+	UsagePhaseTranslate  = "translate"
+	UsagePhaseRevise     = "revise"
+	UsagePhaseSocial     = "social"
+)
+
+// Usage report groupings for SummarizeUsage.
+const (
+	UsageByMode  = "mode"
+	UsageByModel = "model"
+	UsageByPhase = "phase"
+)
+
+// modelPrice is the list price of a model in USD.
+type modelPrice struct {
+	// input and output are per million tokens
+	input, output float64
+	// cacheRead and cacheWrite are per million input tokens read from or
+	// written to the prompt cache
+	cacheRead, cacheWrite float64
+	// perMinute is per minute of audio
+	perMinute float64
+}
+
+// modelPrices are matched by the longest prefix of the model ID, so dated
+// snapshots such as claude-sonnet-4-5-20250929 share their alias's price.
+var modelPrices = map[string]modelPrice{
+	"claude-opus-4-5":   {input: 5, output: 25, cacheRead: 0.5, cacheWrite: 6.25},
+	"claude-opus-4":     {input: 15, output: 75, cacheRead: 1.5, cacheWrite: 18.75},
+	"claude-sonnet-4":   {input: 3, output: 15, cacheRead: 0.3, cacheWrite: 3.75},
+	"claude-3-7-sonnet": {input: 3, output: 15, cacheRead: 0.3, cacheWrite: 3.75},
+	"claude-haiku-4-5":  {input: 1, output: 5, cacheRead: 0.1, cacheWrite: 1.25},
+	"claude-3-5-haiku":  {input: 0.8, output: 4, cacheRead: 0.08, cacheWrite: 1},
+	"gpt-4.1":           {input: 2, output: 8, cacheRead: 0.5},
+	"gpt-4.1-mini":      {input: 0.4, output: 1.6, cacheRead: 0.1},
+	"gpt-4.1-nano":      {input: 0.1, output: 0.4, cacheRead: 0.025},
+	"gpt-4o":            {input: 2.5, output: 10, cacheRead: 1.25},
+	"gpt-4o-mini":       {input: 0.15, output: 0.6, cacheRead: 0.075},
+	"whisper-1":         {perMinute: 0.006},
+}
+
+// priceOf returns the price of model, matched by the longest prefix.
+func priceOf(model string) (modelPrice, bool) {
+	var price modelPrice
+	matched := ""
+	for prefix, p := range modelPrices {
+		if strings.HasPrefix(model, prefix) && len(prefix) > len(matched) {
+			matched, price = prefix, p
+		}
+	}
+
+	return price, matched != ""
+}
+
+// EstimateCost returns the list price in USD of a request to model. Unknown
+// models cost nothing and report ok=false.
+func EstimateCost(model string, inputTokens, outputTokens int64, audioSeconds float64) (float64, bool) {
+	price, ok := priceOf(model)
+	if !ok {
+		return 0, false
+	}
+
+	return float64(inputTokens)/1e6*price.input +
+		float64(outputTokens)/1e6*price.output +
+		audioSeconds/60*price.perMinute, true
+}
+
+// EstimateCacheCost returns the list price in USD of input tokens read from
+// and written to model's prompt cache. Unknown models cost nothing.
+func EstimateCacheCost(model string, readTokens, writeTokens int64) float64 {
+	price, _ := priceOf(model)
+
+	return float64(readTokens)/1e6*price.cacheRead + float64(writeTokens)/1e6*price.cacheWrite
+}
+
+// UsageRecord is one API request in the usage ledger.
+type UsageRecord struct {
+	Time time.Time `json:"time"`
+	// Session is the working name of the memo the request was made for.
+	Session      string  `json:"session,omitempty"`
+	Mode         Mode    `json:"mode,omitempty"`
+	Phase        string  `json:"phase"`
+	Model        string  `json:"model"`
+	InputTokens  int64   `json:"input_tokens,omitempty"`
+	OutputTokens int64   `json:"output_tokens,omitempty"`
+	AudioSeconds float64 `json:"audio_seconds,omitempty"`
+	// CacheReadTokens and CacheWriteTokens are input tokens that hit or
+	// missed the prompt cache; InputTokens leaves them out.
+	CacheReadTokens  int64 `json:"cache_read_tokens,omitempty"`
+	CacheWriteTokens int64 `json:"cache_write_tokens,omitempty"`
+	// Cost is the estimated price in USD when the request was made.
+	Cost float64 `json:"cost_usd"`
+}
+
+// UsageRecorder receives the usage of API requests. Implementations must be
+// safe for concurrent use.
+type UsageRecorder interface {
+	Record(record UsageRecord)
+}
+
+// UsageTotals sums the usage of several requests.
+type UsageTotals struct {
+	// Key is the mode, model or phase the totals are grouped by.
+	Key              string
+	Requests         int
+	InputTokens      int64
+	OutputTokens     int64
+	CacheReadTokens  int64
+	CacheWriteTokens int64
+	AudioSeconds     float64
+	Cost             float64
+}
+
+// Add counts the record in the totals.
+func (t *UsageTotals) Add(record UsageRecord) {
+	t.Requests++
+	t.InputTokens += record.InputTokens
+	t.OutputTokens += record.OutputTokens
+	t.CacheReadTokens += record.CacheReadTokens
+	t.CacheWriteTokens += record.CacheWriteTokens
+	t.AudioSeconds += record.AudioSeconds
+	t.Cost += record.Cost
+}
+
+// String renders the totals for a status line, e.g.
+// "$0.0421 (3 requests, 12,345 input / 2,345 output tokens, 3m12s audio)".
+// Prompt cache use is added as e.g. "8,000 cache hit / 4,000 miss tokens".
+func (t UsageTotals) String() string {
+	parts := []string{fmt.Sprintf("%d requests", t.Requests)}
+	if t.InputTokens > 0 || t.OutputTokens > 0 {
+		parts = append(parts, fmt.Sprintf("%s input / %s output tokens",
+			FormatCount(t.InputTokens), FormatCount(t.OutputTokens)))
+	}
+	if t.CacheReadTokens > 0 || t.CacheWriteTokens > 0 {
+		parts = append(parts, fmt.Sprintf("%s cache hit / %s miss tokens",
+			FormatCount(t.CacheReadTokens), FormatCount(t.CacheWriteTokens)))
+	}
+	if t.AudioSeconds > 0 {
+		parts = append(parts, FormatAudio(t.AudioSeconds)+" audio")
+	}
+
+	return fmt.Sprintf("$%.4f (%s)", t.Cost, strings.Join(parts, ", "))
+}
+
+// FormatCount renders n with thousands separators, e.g. "12,345".
+func FormatCount(n int64) string {
+	digits := fmt.Sprint(n)
+	for i := len(digits) - 3; i > 0 && digits[i-1] != '-'; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+
+	return digits
+}
+
+// FormatAudio renders an audio duration rounded to the second, e.g. "3m12s".
+func FormatAudio(seconds float64) string {
+	return (time.Duration(seconds * float64(time.Second))).Round(time.Second).String()
+}
+
+// UsageLedger appends usage records to a JSONL file and keeps the totals of
+// the current session. A nil ledger records nothing.
+type UsageLedger struct {
+	path    string
+	session string
+	mode    Mode
+
+	mu     sync.Mutex
+	totals UsageTotals
+}
+
+// NewUsageLedger creates a ledger writing to path. Records without a session
+// or mode are attributed to the given ones. The file is created on the first
+// record.
+func NewUsageLedger(path, session string, mode Mode) *UsageLedger {
+	return &UsageLedger{path: path, session: session, mode: mode}
+}
+
+// Record stamps, prices and appends the record. Failures to write are logged,
+// since losing a ledger entry must not fail the request it describes.
+func (l *UsageLedger) Record(record UsageRecord) {
+	if l == nil {
+		return
+	}
+
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	if record.Session == "" {
+		record.Session = l.session
+	}
+	if record.Mode == "" {
+		record.Mode = l.mode
+	}
+
+	cost, ok := EstimateCost(record.Model, record.InputTokens, record.OutputTokens, record.AudioSeconds)
+	if !ok {
+		slog.Debug("no price for model, recording zero cost", "model", record.Model)
+	}
+	record.Cost = cost + EstimateCacheCost(record.Model, record.CacheReadTokens, record.CacheWriteTokens)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.totals.Add(record)
+
+	if err := l.append(record); err != nil {
+		slog.Warn("failed to write usage ledger", "path", l.path, "error", err)
+	}
+}
+
+func (l *UsageLedger) append(record UsageRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode usage record: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create usage ledger directory: %w", err)
+	}
+
+	//nolint:gosec // The ledger holds no secrets
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open usage ledger: %w", err)
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to append usage record: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close usage ledger: %w", err)
+	}
+
+	return nil
+}
+
+// Session returns the totals of the records made through this ledger.
+func (l *UsageLedger) Session() UsageTotals {
+	if l == nil {
+		return UsageTotals{}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.totals
+}
+
+// ReadUsage returns the ledger records made at or after since. A missing
+// ledger has no records; malformed lines are skipped.
+func ReadUsage(path string, since time.Time) ([]UsageRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to open usage ledger: %w", err)
+	}
+	defer f.Close()
+
+	var records []UsageRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record UsageRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			slog.Debug("skipping malformed usage record", "error", err)
+			continue
+		}
+
+		if !record.Time.Before(since) {
+			records = append(records, record)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage ledger: %w", err)
+	}
+
+	return records, nil
+}
+
+// SummarizeUsage groups records by mode, model or phase, most expensive
+// first.
+func SummarizeUsage(records []UsageRecord, by string) ([]UsageTotals, error) {
+	var keyOf func(UsageRecord) string
+	switch by {
+	case UsageByMode:
+		keyOf = func(r UsageRecord) string { return string(r.Mode) }
+	case UsageByModel:
+		keyOf = func(r UsageRecord) string { return r.Model }
+	case UsageByPhase:
+		keyOf = func(r UsageRecord) string { return r.Phase }
+	default:
+		return nil, fmt.Errorf("invalid grouping %q: must be one of %s, %s, %s",
+			by, UsageByMode, UsageByModel, UsageByPhase)
+	}
+
+	groups := map[string]*UsageTotals{}
+	for _, record := range records {
+		key := keyOf(record)
+		if key == "" {
+			key = "(none)"
+		}
+
+		if groups[key] == nil {
+			groups[key] = &UsageTotals{Key: key}
+		}
+		groups[key].Add(record)
+	}
+
+	totals := make([]UsageTotals, 0, len(groups))
+	for _, group := range groups {
+		totals = append(totals, *group)
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Cost != totals[j].Cost {
+			return totals[i].Cost > totals[j].Cost
+		}
+		return totals[i].Key < totals[j].Key
+	})
+
+	return totals, nil
+}
+
+type usageLabelKey struct{}
+
+// usageLabel attributes a Writer request to a workflow phase and mode.
+type usageLabel struct {
+	phase string
+	mode  Mode
+}
+
+// withUsageLabel returns a context whose requests are recorded under phase
+// and mode.
+func withUsageLabel(ctx context.Context, phase string, mode Mode) context.Context {
+	return context.WithValue(ctx, usageLabelKey{}, usageLabel{phase: phase, mode: mode})
+}
+
+// recordUsage logs prompt cache use and reports the tokens used by resp to
+// the configured recorder.
+func (w *Writer) recordUsage(ctx context.Context, resp *ChatResponse) {
+	if resp == nil {
+		return
+	}
+
+	label, _ := ctx.Value(usageLabelKey{}).(usageLabel)
+	if resp.CacheReadTokens > 0 || resp.CacheWriteTokens > 0 {
+		slog.Debug("Prompt cache", "phase", label.phase, "model", resp.Model, "hit_tokens", resp.CacheReadTokens,
+			"miss_tokens", resp.CacheWriteTokens, "uncached_tokens", resp.InputTokens)
+	}
+
+	if w.config.Usage == nil {
+		return
+	}
+
+	w.config.Usage.Record(UsageRecord{
+		Phase:            label.phase,
+		Mode:             label.mode,
+		Model:            resp.Model,
+		InputTokens:      resp.InputTokens,
+		OutputTokens:     resp.OutputTokens,
+		CacheReadTokens:  resp.CacheReadTokens,
+		CacheWriteTokens: resp.CacheWriteTokens,
+	})
+}
@@ -0,0 +1,155 @@
+package content
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCost(t *testing.T) {
+	cost, ok := EstimateCost("claude-sonnet-4-5-20250929", 1_000_000, 100_000, 0)
+	require.True(t, ok)
+	assert.InDelta(t, 4.5, cost, 1e-9)
+
+	// The longest prefix wins over claude-opus-4
+	cost, ok = EstimateCost("claude-opus-4-5", 1_000_000, 0, 0)
+	require.True(t, ok)
+	assert.InDelta(t, 5, cost, 1e-9)
+
+	cost, ok = EstimateCost("whisper-1", 0, 0, 90)
+	require.True(t, ok)
+	assert.InDelta(t, 0.009, cost, 1e-9)
+
+	_, ok = EstimateCost("llama3", 1000, 1000, 0)
+	assert.False(t, ok)
+}
+
+func TestEstimateCacheCost(t *testing.T) {
+	assert.InDelta(t, 0.3+3.75, EstimateCacheCost("claude-sonnet-4-5", 1_000_000, 1_000_000), 1e-9)
+	assert.InDelta(t, 0.5, EstimateCacheCost("gpt-4.1-2025-04-14", 1_000_000, 0), 1e-9)
+	assert.Zero(t, EstimateCacheCost("llama3", 1000, 1000))
+}
+
+func TestUsageLedger_RecordAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memos", "usage.jsonl")
+	ledger := NewUsageLedger(path, "my-memo", ModeMemos)
+
+	ledger.Record(UsageRecord{Phase: PhaseFirstDraft, Model: "claude-haiku-4-5", InputTokens: 1000, OutputTokens: 200})
+	ledger.Record(UsageRecord{Phase: UsagePhaseTranscribe, Model: "whisper-1", AudioSeconds: 60})
+	ledger.Record(UsageRecord{
+		Time:  time.Now().Add(-48 * time.Hour),
+		Mode:  ModeJournal,
+		Phase: PhaseCopyEdit,
+		Model: "claude-haiku-4-5",
+	})
+
+	session := ledger.Session()
+	assert.Equal(t, 3, session.Requests)
+	assert.InDelta(t, 0.001+0.001+0.006, session.Cost, 1e-9)
+
+	records, err := ReadUsage(path, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, records, 2, "Older records are filtered out")
+	assert.Equal(t, "my-memo", records[0].Session)
+	assert.Equal(t, ModeMemos, records[1].Mode, "Records default to the ledger's mode")
+	assert.InDelta(t, 0.006, records[1].Cost, 1e-9)
+
+	all, err := ReadUsage(path, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, ModeJournal, all[2].Mode)
+}
+
+func TestReadUsage_MissingAndMalformed(t *testing.T) {
+	dir := t.TempDir()
+
+	records, err := ReadUsage(filepath.Join(dir, "none.jsonl"), time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	path := filepath.Join(dir, "usage.jsonl")
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(path, []byte("not json\n{\"phase\":\"revise\",\"model\":\"m\"}\n"), 0o644))
+
+	records, err = ReadUsage(path, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, UsagePhaseRevise, records[0].Phase)
+}
+
+func TestSummarizeUsage(t *testing.T) {
+	records := []UsageRecord{
+		{Mode: ModeMemos, Phase: PhaseFirstDraft, Model: "a", InputTokens: 10, Cost: 1},
+		{Mode: ModeMemos, Phase: PhaseCopyEdit, Model: "b", OutputTokens: 5, Cost: 3},
+		{Mode: ModeJournal, Phase: PhaseFirstDraft, Model: "a", AudioSeconds: 30, Cost: 0.5},
+	}
+
+	byModel, err := SummarizeUsage(records, UsageByModel)
+	require.NoError(t, err)
+	assert.Equal(t, []UsageTotals{
+		{Key: "b", Requests: 1, OutputTokens: 5, Cost: 3},
+		{Key: "a", Requests: 2, InputTokens: 10, AudioSeconds: 30, Cost: 1.5},
+	}, byModel)
+
+	byPhase, err := SummarizeUsage(records, UsageByPhase)
+	require.NoError(t, err)
+	assert.Equal(t, PhaseCopyEdit, byPhase[0].Key)
+
+	_, err = SummarizeUsage(records, "day")
+	assert.ErrorContains(t, err, "invalid grouping")
+}
+
+func TestUsageTotals_String(t *testing.T) {
+	totals := UsageTotals{Requests: 3, InputTokens: 12345, OutputTokens: 2345, AudioSeconds: 192.4, Cost: 0.04213}
+
+	assert.Equal(t, "$0.0421 (3 requests, 12,345 input / 2,345 output tokens, 3m12s audio)", totals.String())
+}
+
+func TestUsageTotals_StringCache(t *testing.T) {
+	totals := UsageTotals{
+		Requests:         2,
+		InputTokens:      300,
+		OutputTokens:     500,
+		CacheReadTokens:  8000,
+		CacheWriteTokens: 4000,
+	}
+
+	assert.Equal(t, "$0.0000 (2 requests, 300 input / 500 output tokens, 8,000 cache hit / 4,000 miss tokens)",
+		totals.String())
+}
+
+type usageRecorderFunc func(UsageRecord)
+
+func (f usageRecorderFunc) Record(record UsageRecord) { f(record) }
+
+func TestWriter_RecordUsage(t *testing.T) {
+	var recorded []UsageRecord
+	writer := NewWriter(NewAnthropicProvider("test-api-key"), WriterConfig{
+		Usage: usageRecorderFunc(func(r UsageRecord) { recorded = append(recorded, r) }),
+	})
+
+	resp := &ChatResponse{
+		Text:             "draft",
+		Model:            "claude-sonnet-4-5",
+		InputTokens:      100,
+		OutputTokens:     20,
+		CacheReadTokens:  3000,
+		CacheWriteTokens: 50,
+	}
+
+	writer.recordUsage(withUsageLabel(context.Background(), PhaseFirstDraft, ModeJournal), resp)
+
+	assert.Equal(t, []UsageRecord{{
+		Mode:             ModeJournal,
+		Phase:            PhaseFirstDraft,
+		Model:            "claude-sonnet-4-5",
+		InputTokens:      100,
+		OutputTokens:     20,
+		CacheReadTokens:  3000,
+		CacheWriteTokens: 50,
+	}}, recorded)
+}
@@ -0,0 +1,146 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultCopyEditRepairs is how many times a copy edit failing validation is
+// sent back to the model with the problems found.
+const DefaultCopyEditRepairs = 2
+
+var (
+	footnotePattern  = regexp.MustCompile(`\[\^([^\]\s]+)\]`)
+	shortcodePattern = regexp.MustCompile(`\{\{[<%]\s*/?\s*([\w-]+)`)
+)
+
+// ValidateCopyEdit checks a copy-edited post against the mode: its
+// frontmatter must parse and have the mode's fields, its date must be
+// YYYY-MM-DD or RFC 3339, every footnote of source must survive and the
+// mode's shortcodes must be present. Returns the problems found, each a short
+// sentence.
+func ValidateCopyEdit(source, markdown string, mode ModeConfig) []string {
+	var problems []string
+
+	if fm, _, err := ParseFrontmatter(markdown); err != nil {
+		problems = append(problems, "Invalid frontmatter: "+err.Error())
+	} else {
+		if missing := MissingFrontmatter(markdown, mode.Frontmatter); len(missing) > 0 {
+			problems = append(problems, "Missing frontmatter: "+strings.Join(missing, ", "))
+		}
+
+		if fm.Date != "" && !validDate(fm.Date) {
+			problems = append(problems, fmt.Sprintf("Date %q is not in YYYY-MM-DD format", fm.Date))
+		}
+	}
+
+	if dropped := droppedFootnotes(source, markdown); len(dropped) > 0 {
+		problems = append(problems, "Dropped footnotes: "+strings.Join(dropped, ", "))
+	}
+
+	for _, shortcode := range mode.Shortcodes {
+		if !hasShortcode(markdown, shortcode) {
+			problems = append(problems, fmt.Sprintf("Missing shortcode: {{< %s >}}", shortcode))
+		}
+	}
+
+	return problems
+}
+
+func validDate(date string) bool {
+	if _, err := time.Parse(time.DateOnly, date); err == nil {
+		return true
+	}
+
+	_, err := time.Parse(time.RFC3339, date)
+
+	return err == nil
+}
+
+// droppedFootnotes returns the footnote labels of source, e.g. [^1], that
+// markdown no longer has, in order of appearance.
+func droppedFootnotes(source, markdown string) []string {
+	kept := map[string]bool{}
+	for _, match := range footnotePattern.FindAllStringSubmatch(markdown, -1) {
+		kept[match[1]] = true
+	}
+
+	var dropped []string
+	for _, match := range footnotePattern.FindAllStringSubmatch(source, -1) {
+		if !kept[match[1]] {
+			kept[match[1]] = true
+			dropped = append(dropped, match[0])
+		}
+	}
+
+	return dropped
+}
+
+func hasShortcode(markdown, name string) bool {
+	for _, match := range shortcodePattern.FindAllStringSubmatch(markdown, -1) {
+		if match[1] == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// repairRequest asks the model to fix the problems found in its copy edit.
+func repairRequest(problems []string) string {
+	return "The copy edit failed validation:\n- " + strings.Join(problems, "\n- ") +
+		"\n\nFix these problems and call save_copy_edit again with the complete post."
+}
+
+// messageFunc sends a request; see Writer.chat.
+type messageFunc func(ctx context.Context, req ChatRequest) (*ChatResponse, error)
+
+// validatedCopyEdit requests a copy edit and, while validate reports
+// problems, returns them to the model as a tool error to fix, at most repairs
+// times. The last attempt is returned even if problems remain.
+func validatedCopyEdit(
+	ctx context.Context,
+	req ChatRequest,
+	send messageFunc,
+	validate func(markdown string) []string,
+	repairs int,
+) (*CopyEditToolInput, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := send(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		// A truncated tool call holds an incomplete post
+		if resp.Truncated {
+			return nil, truncationError("the copy edit", req.Params.MaxTokens, "--copy-edit-max-tokens")
+		}
+
+		toolInput, err := parseCopyEditToolCall(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		problems := validate(toolInput.Markdown)
+		if len(problems) == 0 {
+			return toolInput, nil
+		}
+
+		if attempt >= repairs {
+			slog.Warn("Copy edit still fails validation", "problems", problems, "repairs", attempt)
+			return toolInput, nil
+		}
+
+		slog.Debug("Copy edit failed validation, requesting a repair", "problems", problems, "attempt", attempt+1)
+
+		result := &ToolResult{CallID: resp.ToolCall.ID, Text: repairRequest(problems), IsError: true}
+		req.Messages = append(req.Messages[:len(req.Messages):len(req.Messages)],
+			ChatMessage{Assistant: true, ToolCall: resp.ToolCall},
+			ChatMessage{ToolResult: result},
+		)
+	}
+}
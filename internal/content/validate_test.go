@@ -0,0 +1,131 @@
+package content
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validPost = `---
+title: "Footnotes"
+date: 2026-10-18
+tags: ["Go"]
+voiceBased: true
+pinned: false
+draft: false
+---
+
+Claims need sources.[^1]
+
+[^1]: A source.
+
+---
+{{< byline >}}
+`
+
+func TestValidateCopyEdit_Valid(t *testing.T) {
+	mode := DefaultModes()[ModeMemos]
+
+	assert.Empty(t, ValidateCopyEdit("Claims need sources.[^1]\n\n[^1]: A source.", validPost, mode))
+}
+
+func TestValidateCopyEdit_Problems(t *testing.T) {
+	mode := DefaultModes()[ModeMemos]
+	source := "One.[^1] Two.[^note]\n\n[^1]: First.\n[^note]: Second."
+	markdown := "---\ntitle: \"Broken\"\ndate: October 18\n---\n\nOne.[^1]\n\n[^1]: First."
+
+	assert.Equal(t, []string{
+		"Missing frontmatter: tags, voiceBased, pinned, draft",
+		`Date "October 18" is not in YYYY-MM-DD format`,
+		"Dropped footnotes: [^note]",
+		"Missing shortcode: {{< byline >}}",
+	}, ValidateCopyEdit(source, markdown, mode))
+}
+
+func TestValidateCopyEdit_NoFrontmatter(t *testing.T) {
+	mode := ModeConfig{Name: "notes"}.WithDefaults()
+
+	problems := ValidateCopyEdit("", "Just text.", mode)
+
+	assert.Equal(t, []string{"Invalid frontmatter: missing frontmatter block"}, problems)
+}
+
+func TestValidateCopyEdit_RFC3339Date(t *testing.T) {
+	mode := ModeConfig{Name: "notes"}.WithDefaults()
+
+	assert.Empty(t, ValidateCopyEdit("", "---\ntitle: Notes\ndate: 2026-10-18T09:30:00Z\n---\n\nBody.", mode))
+}
+
+// toolCallResponse builds a save_copy_edit response.
+func toolCallResponse(id, markdown string, truncated bool) *ChatResponse {
+	input := `{"title": "Post", "markdown": "` + markdown + `", "changes": []}`
+
+	return &ChatResponse{
+		ToolCall:  &ToolCall{ID: id, Name: "save_copy_edit", Input: []byte(input)},
+		Truncated: truncated,
+	}
+}
+
+func TestValidatedCopyEdit_Repairs(t *testing.T) {
+	responses := []*ChatResponse{
+		toolCallResponse("toolu_1", "broken", false),
+		toolCallResponse("toolu_2", "fixed", false),
+	}
+	var requests []ChatRequest
+	send := func(_ context.Context, req ChatRequest) (*ChatResponse, error) {
+		requests = append(requests, req)
+		return responses[len(requests)-1], nil
+	}
+	validate := func(markdown string) []string {
+		if markdown == "broken" {
+			return []string{"Missing shortcode: {{< byline >}}"}
+		}
+		return nil
+	}
+
+	req := ChatRequest{Messages: []ChatMessage{{Text: "draft"}}}
+	toolInput, err := validatedCopyEdit(context.Background(), req, send, validate, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, "fixed", toolInput.Markdown)
+
+	require.Len(t, requests, 2)
+	require.Len(t, requests[1].Messages, 3, "The repair request replays the failed tool call")
+	assert.True(t, requests[1].Messages[1].Assistant)
+	assert.Equal(t, responses[0].ToolCall, requests[1].Messages[1].ToolCall)
+
+	toolResult := requests[1].Messages[2].ToolResult
+	require.NotNil(t, toolResult)
+	assert.Equal(t, "toolu_1", toolResult.CallID)
+	assert.True(t, toolResult.IsError)
+	assert.Contains(t, toolResult.Text, "- Missing shortcode: {{< byline >}}")
+}
+
+func TestValidatedCopyEdit_GivesUp(t *testing.T) {
+	calls := 0
+	send := func(context.Context, ChatRequest) (*ChatResponse, error) {
+		calls++
+		return toolCallResponse("toolu_1", "broken", false), nil
+	}
+	validate := func(string) []string { return []string{"Dropped footnotes: [^1]"} }
+
+	toolInput, err := validatedCopyEdit(context.Background(), ChatRequest{}, send, validate, 1)
+
+	require.NoError(t, err, "The last attempt is kept even if it is still invalid")
+	assert.Equal(t, "broken", toolInput.Markdown)
+	assert.Equal(t, 2, calls)
+}
+
+func TestValidatedCopyEdit_Truncated(t *testing.T) {
+	send := func(context.Context, ChatRequest) (*ChatResponse, error) {
+		return toolCallResponse("toolu_1", "cut off", true), nil
+	}
+
+	req := ChatRequest{Params: GenerationParams{MaxTokens: 100}}
+	_, err := validatedCopyEdit(context.Background(), req, send, nil, 2)
+
+	require.ErrorIs(t, err, ErrTruncated)
+	assert.ErrorContains(t, err, "--copy-edit-max-tokens")
+}
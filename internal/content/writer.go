@@ -5,203 +5,596 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-
-	"github.com/anthropics/anthropic-sdk-go"
-	"github.com/anthropics/anthropic-sdk-go/option"
+	"log/slog"
+	"strings"
 )
 
-// Writer handles Anthropic API requests for content generation.
-type Writer struct {
-	apiKey string
-	model  anthropic.Model
+// DefaultMaxTokens caps responses of phases without a configured limit.
+const DefaultMaxTokens = 4096
+
+// GenerationParams controls the model and sampling of a Writer request.
+type GenerationParams struct {
+	// Model is a model ID of the provider, e.g. "claude-haiku-4-5". Empty uses
+	// the provider's default model.
+	Model string
+	// MaxTokens caps the response length. Zero uses DefaultMaxTokens.
+	MaxTokens int64
+	// Temperature overrides the API default when set.
+	Temperature *float64
 }
 
-// NewWriter creates a new AI client.
-func NewWriter(apiKey string) *Writer {
-	return &Writer{
-		apiKey: apiKey,
-		model:  anthropic.ModelClaudeSonnet4_5_20250929,
+// WithDefaults returns params with default values applied to zero fields.
+// model is the default model of the provider.
+func (p GenerationParams) WithDefaults(model string) GenerationParams {
+	if p.Model == "" {
+		p.Model = model
+	}
+
+	if p.MaxTokens == 0 {
+		p.MaxTokens = DefaultMaxTokens
 	}
+
+	return p
 }
 
-// Mode represents the content generation mode for blog posts.
-type Mode string
+// WriterConfig sets generation parameters separately for each phase.
+type WriterConfig struct {
+	FirstDraft GenerationParams
+	CopyEdit   GenerationParams
 
-const (
-	// ModeMemos is the default mode with full frontmatter (tags, voiceBased, etc.).
-	ModeMemos Mode = "memos"
-	// ModeJournal is a minimal mode for personal journal entries.
-	ModeJournal Mode = "journal"
-)
+	// Prompts renders the system prompts. Nil uses DefaultPrompts.
+	Prompts *Prompts
+	// PromptData supplies the author and tags; Date and Mode are set per request.
+	PromptData PromptData
+	// Modes selects prompts by mode. Nil uses DefaultModes.
+	Modes Modes
+	// Exemplars are existing posts shown as style examples when drafting and
+	// copy editing. See SelectExemplars.
+	Exemplars []Post
+	// DraftChunkTokens is the estimated transcript size above which the first
+	// draft is written part by part. Zero uses DefaultDraftChunkTokens; a
+	// negative value always drafts in one request.
+	DraftChunkTokens int
+	// CopyEditRepairs caps how often a copy edit failing ValidateCopyEdit is
+	// sent back to be fixed. Zero uses DefaultCopyEditRepairs; a negative
+	// value never repairs.
+	CopyEditRepairs int
+	// Usage, when set, records the tokens of every request.
+	Usage UsageRecorder
+	// Posts, when set, is searched for earlier posts a copy edit may link to.
+	// Links are suggested in CopyEditResult.CrossLinks, not inserted.
+	Posts *PostIndex
+}
+
+// Writer generates content through an LLM provider.
+type Writer struct {
+	provider Provider
+	config   WriterConfig
+	retry    RetryPolicy
+}
+
+// NewWriter creates a writer sending requests to provider. Zero fields of
+// config use defaults.
+func NewWriter(provider Provider, config WriterConfig) *Writer {
+	config.FirstDraft = config.FirstDraft.WithDefaults(provider.DefaultModel())
+	config.CopyEdit = config.CopyEdit.WithDefaults(provider.DefaultModel())
+	if config.Prompts == nil {
+		config.Prompts = DefaultPrompts()
+	}
+	if config.Modes == nil {
+		config.Modes = DefaultModes()
+	}
+	if config.DraftChunkTokens == 0 {
+		config.DraftChunkTokens = DefaultDraftChunkTokens
+	}
+	if config.CopyEditRepairs == 0 {
+		config.CopyEditRepairs = DefaultCopyEditRepairs
+	}
+
+	return &Writer{
+		provider: provider,
+		config:   config,
+		retry:    DefaultRetryPolicy(),
+	}
+}
 
 // CopyEditToolInput defines the tool input schema for copy-edit.
 type CopyEditToolInput struct {
-	Title    string   `json:"title"`
-	Markdown string   `json:"markdown"`
-	Changes  []string `json:"changes"`
+	Title           string               `json:"title"`
+	TitleCandidates []string             `json:"title_candidates"`
+	Markdown        string               `json:"markdown"`
+	Changes         []string             `json:"changes"`
+	ExistingTags    []string             `json:"existing_tags"`
+	NewTags         []string             `json:"new_tags"`
+	Description     string               `json:"description"`
+	SocialBlurb     string               `json:"social_blurb"`
+	CrossLinks      []CrossLinkToolInput `json:"cross_links"`
+}
+
+// CrossLinkToolInput is a link to an earlier post suggested in the copy-edit
+// tool input.
+type CrossLinkToolInput struct {
+	Slug string `json:"slug"`
+	Text string `json:"text"`
 }
 
 // CopyEditResult wraps the output from GenerateCopyEdit.
 type CopyEditResult struct {
-	Title    string
-	Markdown string
-	Changes  []string
-}
-
-// getCopyEditTool returns the tool definition for copy-edit structured output.
-func getCopyEditTool() anthropic.ToolParam {
-	return anthropic.ToolParam{
-		Name: "save_copy_edit",
-		Description: anthropic.String(
-			"Save the copy-edited blog post with title, markdown content, and list of changes",
-		),
-		InputSchema: anthropic.ToolInputSchemaParam{
-			Type: "object",
-			Properties: map[string]interface{}{
-				"title": map[string]interface{}{
-					"type":        "string",
-					"description": "The blog post title (extracted from or to be used in frontmatter)",
+	Title string
+	// TitleCandidates holds Title followed by the model's alternatives, any
+	// of which can replace it with WithTitle.
+	TitleCandidates []string
+	Markdown        string
+	Changes         []string
+	// ExistingTags are tags from the blog's taxonomy; they are set in the
+	// markdown's frontmatter.
+	ExistingTags []string
+	// NewTags are proposed tags the blog has not used yet. They are left out
+	// of the markdown until accepted with WithTags.
+	NewTags []string
+	// Metadata is merged into the markdown's frontmatter.
+	Metadata PostMetadata
+	// CrossLinks are suggested links to earlier posts. They are left out of
+	// the markdown until approved with WithLinks.
+	CrossLinks []CrossLink
+}
+
+// WithTags returns the markdown with the existing tags plus the accepted new
+// ones in its frontmatter. Without accepted tags the markdown is unchanged.
+func (r *CopyEditResult) WithTags(accepted []string) (string, error) {
+	if len(accepted) == 0 {
+		return r.Markdown, nil
+	}
+
+	tags := append(append([]string{}, r.ExistingTags...), accepted...)
+
+	return SetFrontmatterTags(r.Markdown, tags)
+}
+
+// WithTitle returns a copy of the result using title, setting it in the
+// frontmatter and in a leading heading that repeats the old title.
+func (r *CopyEditResult) WithTitle(title string) (*CopyEditResult, error) {
+	if title == r.Title {
+		return r, nil
+	}
+
+	markdown, err := SetTitle(r.Markdown, r.Title, title)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *r
+	result.Title = title
+	result.Markdown = markdown
+
+	return &result, nil
+}
+
+// WithLinks returns a copy of the result with the approved links inserted
+// into the markdown. Links whose text is no longer found are skipped.
+func (r *CopyEditResult) WithLinks(links []CrossLink) *CopyEditResult {
+	result := *r
+	for _, link := range links {
+		var ok bool
+		if result.Markdown, ok = InsertCrossLink(result.Markdown, link); !ok {
+			slog.Warn("link text not found in post", "text", link.Text, "slug", link.Slug)
+		}
+	}
+
+	return &result
+}
+
+// copyEditTool returns the tool definition for copy-edit structured output.
+func copyEditTool() *Tool {
+	return &Tool{
+		Name:        "save_copy_edit",
+		Description: "Save the copy-edited blog post with title, markdown content, and list of changes",
+		Properties: map[string]any{
+			"title": map[string]any{
+				"type":        "string",
+				"description": "The blog post title (extracted from or to be used in frontmatter)",
+			},
+			"title_candidates": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
+				},
+				"description": "Two to four alternative titles for the author to choose from, " +
+					"each taking a different angle or tone",
+			},
+			"markdown": map[string]any{
+				"type":        "string",
+				"description": "The complete markdown file including frontmatter and content",
+			},
+			"changes": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
 				},
-				"markdown": map[string]interface{}{
-					"type":        "string",
-					"description": "The complete markdown file including frontmatter and content",
+				"description": "Bullet-point list of changes made during copy-edit",
+			},
+			"existing_tags": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
+				},
+				"description": "Tags for the post chosen from the blog's existing tags, spelled exactly as listed",
+			},
+			"new_tags": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
 				},
-				"changes": map[string]interface{}{
-					"type": "array",
-					"items": map[string]interface{}{
-						"type": "string",
+				"description": "Proposed tags the blog has not used yet; only when no existing tag fits. " +
+					"Do not put these in the frontmatter",
+			},
+			"description": map[string]any{
+				"type": "string",
+				"description": fmt.Sprintf("One or two plain sentences of at most %d characters describing the post "+
+					"for search results and link previews", MaxDescriptionLength),
+			},
+			"social_blurb": map[string]any{
+				"type": "string",
+				"description": "Optional teaser of one to three sentences for sharing the post on social sites, " +
+					"in the author's voice and without hashtags",
+			},
+			"cross_links": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"slug": map[string]any{
+							"type":        "string",
+							"description": "Slug of the earlier post, as listed in related_posts",
+						},
+						"text": map[string]any{
+							"type":        "string",
+							"description": "Phrase of the edited markdown that refers to the post, exactly as written",
+						},
 					},
-					"description": "Bullet-point list of changes made during copy-edit",
+					"required": []string{"slug", "text"},
 				},
+				"description": "Links to earlier posts from related_posts that the post refers back to, " +
+					"at most one per post. Do not add these links to the markdown",
 			},
-			Required: []string{"title", "markdown", "changes"},
 		},
+		Required: []string{"title", "markdown", "changes", "description"},
 	}
 }
 
-// GenerateFirstDraft creates a lightly edited first draft from raw transcript.
-func (w *Writer) GenerateFirstDraft(transcript string, mode Mode) (string, error) {
-	if w.apiKey == "" {
-		return "", errors.New("API key required: set ANTHROPIC_API_KEY or use --api-key")
+// chat sends a request, retrying transient failures.
+func (w *Writer) chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	var resp *ChatResponse
+	err := w.retry.Do(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = w.provider.Chat(ctx, req)
+		if err != nil {
+			return err //nolint:wrapcheck // providers wrap their own errors
+		}
+		w.recordUsage(ctx, resp)
+
+		return nil
+	})
+
+	return resp, err
+}
+
+// renderPrompt renders a system prompt with the writer's prompt data.
+// tags, when non-nil, supplies the existing tags.
+func (w *Writer) renderPrompt(name string, mode ModeConfig, date string, tags *TagIndex) (string, error) {
+	data := w.config.PromptData
+	data.Mode = mode.Name
+	data.Date = date
+	data.Frontmatter = mode.Frontmatter
+	if tags != nil {
+		data.ExistingTags = tags.Tags()
 	}
 
-	client := anthropic.NewClient(option.WithAPIKey(w.apiKey))
+	return w.config.Prompts.Render(name, data)
+}
 
-	// Select appropriate prompt based on mode
-	var systemPrompt string
-	if mode == ModeJournal {
-		systemPrompt = FirstDraftSystemPromptJournal
-	} else {
-		systemPrompt = FirstDraftSystemPromptMemos
+// styledSystem returns the system prompt followed, when configured, by a
+// separate block of style exemplars.
+func (w *Writer) styledSystem(systemPrompt string) []string {
+	system := []string{systemPrompt}
+	if exemplars := exemplarPrompt(w.config.Exemplars); exemplars != "" {
+		system = append(system, exemplars)
 	}
 
-	params := anthropic.MessageNewParams{
-		Model:     w.model,
-		MaxTokens: 4096,
-		System: []anthropic.TextBlockParam{
-			{Text: systemPrompt},
-		},
-		Messages: []anthropic.MessageParam{
-			anthropic.NewUserMessage(anthropic.NewTextBlock(transcript)),
-		},
+	return system
+}
+
+// stream sends a streaming request, retrying transient failures. onText,
+// when non-nil, is called with the text generated so far as tokens arrive; a
+// retry starts over, so the text may shrink back to "".
+func (w *Writer) stream(ctx context.Context, req ChatRequest, onText func(text string)) (*ChatResponse, error) {
+	var resp *ChatResponse
+	err := w.retry.Do(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = w.provider.StreamChat(ctx, req, onText)
+		if err != nil {
+			return err //nolint:wrapcheck // providers wrap their own errors
+		}
+		w.recordUsage(ctx, resp)
+
+		return nil
+	})
+
+	return resp, err
+}
+
+// GenerateFirstDraft creates a lightly edited first draft from raw transcript.
+// The response is streamed; onText, when non-nil, receives the draft so far.
+func (w *Writer) GenerateFirstDraft(
+	ctx context.Context,
+	transcript string,
+	mode Mode,
+	onText func(draft string),
+) (string, error) {
+	if err := w.provider.Validate(); err != nil {
+		return "", err
 	}
 
-	ctx := context.Background()
-	resp, err := client.Messages.New(ctx, params)
+	ctx = withUsageLabel(ctx, PhaseFirstDraft, mode)
+
+	modeConfig, err := w.config.Modes.Lookup(mode)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate first draft via Anthropic API: %w", err)
+		return "", err
 	}
 
-	// Extract text from response
-	if len(resp.Content) == 0 {
-		return "", errors.New("empty response from Anthropic API")
+	systemPrompt, err := w.renderPrompt(modeConfig.FirstDraftPrompt, modeConfig, "", nil)
+	if err != nil {
+		return "", err
 	}
 
-	textBlock, ok := resp.Content[0].AsAny().(anthropic.TextBlock)
-	if !ok {
-		return "", errors.New("unexpected response type from Anthropic API")
+	// Long transcripts are drafted part by part so no single response runs
+	// into the token limit
+	parts := splitTranscript(transcript, w.config.DraftChunkTokens)
+	if len(parts) > 1 {
+		slog.Debug("Drafting long transcript in parts", "parts", len(parts))
 	}
 
-	return textBlock.Text, nil
-}
+	drafts := make([]string, 0, len(parts))
+	for i, part := range parts {
+		// Parts and batch runs share the system prompt, so it is cached
+		req := ChatRequest{
+			System:      w.styledSystem(systemPrompt),
+			Messages:    []ChatMessage{{Text: draftRequest(part, i, len(parts))}},
+			Params:      w.config.FirstDraft,
+			CacheSystem: true,
+		}
 
-// parseCopyEditToolUse extracts CopyEditToolInput from response content blocks.
-func parseCopyEditToolUse(content []anthropic.ContentBlockUnion) (*CopyEditToolInput, error) {
-	for _, block := range content {
-		if toolUse, ok := block.AsAny().(anthropic.ToolUseBlock); ok {
-			var toolInput CopyEditToolInput
-			inputBytes, err := json.Marshal(toolUse.Input)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal tool input: %w", err)
-			}
-			if err := json.Unmarshal(inputBytes, &toolInput); err != nil {
-				return nil, fmt.Errorf("failed to parse tool input: %w", err)
+		var partText func(string)
+		if onText != nil {
+			done := drafts
+			partText = func(text string) { onText(joinDrafts(append(done[:len(done):len(done)], text))) }
+		}
+
+		draft, err := continueDraft(ctx, req, w.stream, partText)
+		if err != nil {
+			if len(parts) > 1 {
+				return "", fmt.Errorf("failed to generate part %d of %d of the first draft: %w", i+1, len(parts), err)
 			}
 
-			return &toolInput, nil
+			return "", fmt.Errorf("failed to generate first draft via %s: %w", w.provider.Name(), err)
 		}
+
+		drafts = append(drafts, draft)
 	}
 
-	return nil, errors.New("no tool use found in Anthropic API response")
+	return joinDrafts(drafts), nil
+}
+
+// joinDrafts merges the drafts of consecutive transcript parts.
+func joinDrafts(drafts []string) string {
+	trimmed := make([]string, 0, len(drafts))
+	for _, draft := range drafts {
+		if draft = strings.TrimSpace(draft); draft != "" {
+			trimmed = append(trimmed, draft)
+		}
+	}
+
+	return strings.Join(trimmed, "\n\n")
+}
+
+// parseCopyEditToolCall extracts CopyEditToolInput from the response's
+// save_copy_edit call.
+func parseCopyEditToolCall(resp *ChatResponse) (*CopyEditToolInput, error) {
+	if resp.ToolCall == nil {
+		return nil, errors.New("no save_copy_edit call found in response")
+	}
+
+	var toolInput CopyEditToolInput
+	if err := json.Unmarshal(resp.ToolCall.Input, &toolInput); err != nil {
+		return nil, fmt.Errorf("failed to parse tool input: %w", err)
+	}
+
+	return &toolInput, nil
 }
 
 // GenerateCopyEdit performs final copy editing and returns the result.
+// Tags are chosen from tags, the blog's taxonomy, and any others are
+// returned separately as proposed new tags.
 func (w *Writer) GenerateCopyEdit(
+	ctx context.Context,
 	firstDraft string,
 	currentDate string,
 	mode Mode,
+	tags *TagIndex,
 ) (*CopyEditResult, error) {
-	if w.apiKey == "" {
-		return nil, errors.New("API key required: set ANTHROPIC_API_KEY or use --api-key")
+	if err := w.provider.Validate(); err != nil {
+		return nil, err
 	}
 
-	client := anthropic.NewClient(option.WithAPIKey(w.apiKey))
-	toolDef := getCopyEditTool()
+	modeConfig, err := w.config.Modes.Lookup(mode)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create tool union param using the SDK constructor
-	tool := anthropic.ToolUnionParamOfTool(toolDef.InputSchema, toolDef.Name)
-	tool.OfTool.Description = toolDef.Description
+	tags = w.requiredTags(tags)
 
-	// Select appropriate prompt based on mode
-	var systemPrompt string
-	if mode == ModeJournal {
-		systemPrompt = CopyEditSystemPromptJournal(currentDate)
-	} else {
-		systemPrompt = CopyEditSystemPromptMemos(currentDate)
+	systemPrompt, err := w.renderPrompt(modeConfig.CopyEditPrompt, modeConfig, currentDate, tags)
+	if err != nil {
+		return nil, err
 	}
 
-	params := anthropic.MessageNewParams{
-		Model:     w.model,
-		MaxTokens: 4096,
-		System: []anthropic.TextBlockParam{
-			{Text: systemPrompt},
-		},
-		Messages: []anthropic.MessageParam{
-			anthropic.NewUserMessage(anthropic.NewTextBlock(firstDraft)),
-		},
-		Tools:      []anthropic.ToolUnionParam{tool},
-		ToolChoice: anthropic.ToolChoiceParamOfTool("save_copy_edit"),
+	validate := func(markdown string) []string {
+		return ValidateCopyEdit(firstDraft, markdown, modeConfig)
 	}
 
-	ctx := context.Background()
-	resp, err := client.Messages.New(ctx, params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate copy edit via Anthropic API: %w", err)
+	ctx = withUsageLabel(ctx, PhaseCopyEdit, mode)
+	related := w.config.Posts.Related(firstDraft, MaxRelatedPosts)
+
+	return w.requestCopyEdit(ctx, w.styledSystem(systemPrompt), firstDraft, related, tags, validate)
+}
+
+// requiredTags adds the tags every post must carry to the taxonomy, so they
+// are never proposed as new.
+func (w *Writer) requiredTags(tags *TagIndex) *TagIndex {
+	if len(w.config.PromptData.Tags) == 0 {
+		return tags
 	}
 
-	// Parse tool use from response
-	if len(resp.Content) == 0 {
-		return nil, errors.New("empty response from Anthropic API")
+	return tags.With(w.config.PromptData.Tags...)
+}
+
+// requestCopyEdit sends a copy edit request and parses the save_copy_edit
+// tool call from the response, separating new tags from those in tags.
+// related posts are listed after userText as link targets. Posts failing
+// validate are sent back for repair; see validatedCopyEdit.
+func (w *Writer) requestCopyEdit(
+	ctx context.Context,
+	system []string,
+	userText string,
+	related []RelatedPost,
+	tags *TagIndex,
+	validate func(markdown string) []string,
+) (*CopyEditResult, error) {
+	// Repairs, revisions and batch runs resend the same instructions
+	req := ChatRequest{
+		System:      system,
+		Messages:    []ChatMessage{{Text: userText + relatedPostsPrompt(related)}},
+		Params:      w.config.CopyEdit,
+		Tool:        copyEditTool(),
+		CacheSystem: true,
+	}
+
+	send := func(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+		resp, err := w.chat(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate copy edit via %s: %w", w.provider.Name(), err)
+		}
+
+		return resp, nil
 	}
 
-	toolInput, err := parseCopyEditToolUse(resp.Content)
+	toolInput, err := validatedCopyEdit(ctx, req, send, validate, w.config.CopyEditRepairs)
 	if err != nil {
 		return nil, err
 	}
 
-	return &CopyEditResult{
-		Title:    toolInput.Title,
-		Markdown: toolInput.Markdown,
-		Changes:  toolInput.Changes,
-	}, nil
+	result := &CopyEditResult{
+		Title:           toolInput.Title,
+		TitleCandidates: titleCandidates(toolInput),
+		Markdown:        toolInput.Markdown,
+		Changes:         toolInput.Changes,
+	}
+	splitTags(result, toolInput, tags)
+	mergeMetadata(result, toolInput)
+	result.CrossLinks = crossLinks(toolInput, related, result.Markdown)
+
+	return result, nil
+}
+
+// titleCandidates lists the chosen title first, then the alternatives without
+// blanks or duplicates.
+func titleCandidates(toolInput *CopyEditToolInput) []string {
+	candidates := []string{toolInput.Title}
+	seen := map[string]bool{strings.ToLower(toolInput.Title): true}
+
+	for _, title := range toolInput.TitleCandidates {
+		title = strings.TrimSpace(title)
+		if title == "" || seen[strings.ToLower(title)] {
+			continue
+		}
+
+		seen[strings.ToLower(title)] = true
+		candidates = append(candidates, title)
+	}
+
+	return candidates
+}
+
+// crossLinks keeps the suggested links to related posts whose text can be
+// linked in markdown, one per post.
+func crossLinks(toolInput *CopyEditToolInput, related []RelatedPost, markdown string) []CrossLink {
+	titles := map[string]string{}
+	for _, post := range related {
+		titles[post.Slug] = post.Title
+	}
+
+	var links []CrossLink
+	for _, suggested := range toolInput.CrossLinks {
+		title, ok := titles[suggested.Slug]
+		if !ok {
+			slog.Debug("dropping link to unknown post", "slug", suggested.Slug)
+			continue
+		}
+
+		link := CrossLink{Slug: suggested.Slug, Title: title, Text: strings.TrimSpace(suggested.Text)}
+		if _, ok := InsertCrossLink(markdown, link); !ok {
+			slog.Debug("dropping link whose text is not in the post", "slug", link.Slug, "text", link.Text)
+			continue
+		}
+
+		delete(titles, suggested.Slug)
+		links = append(links, link)
+	}
+
+	return links
+}
+
+// mergeMetadata fills the result's metadata and merges it into the
+// frontmatter. The reading time is counted rather than left to the model.
+func mergeMetadata(result *CopyEditResult, toolInput *CopyEditToolInput) {
+	result.Metadata = PostMetadata{
+		Description: strings.TrimSpace(toolInput.Description),
+		ReadingTime: ReadingTime(result.Markdown),
+		SocialBlurb: strings.TrimSpace(toolInput.SocialBlurb),
+	}
+
+	if length := len([]rune(result.Metadata.Description)); length > MaxDescriptionLength {
+		slog.Warn("post description is longer than link previews show", "length", length)
+	}
+
+	markdown, err := SetFrontmatterMetadata(result.Markdown, result.Metadata)
+	if err != nil {
+		slog.Warn("failed to set post metadata", "error", err)
+		return
+	}
+	result.Markdown = markdown
+}
+
+// splitTags fills the result's existing and new tags and keeps new tags out
+// of the frontmatter until they are accepted. Without a taxonomy to check
+// against, every tag counts as existing.
+func splitTags(result *CopyEditResult, toolInput *CopyEditToolInput, tags *TagIndex) {
+	all := append(append([]string{}, toolInput.ExistingTags...), toolInput.NewTags...)
+	if len(all) == 0 {
+		return
+	}
+
+	if len(tags.Tags()) == 0 {
+		result.ExistingTags = (*TagIndex)(nil).With(all...).Tags()
+		return
+	}
+
+	result.ExistingTags, result.NewTags = tags.Split(all)
+
+	markdown, err := SetFrontmatterTags(result.Markdown, result.ExistingTags)
+	if err != nil {
+		slog.Warn("failed to set post tags", "error", err)
+		return
+	}
+	result.Markdown = markdown
 }
@@ -0,0 +1,144 @@
+package content
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWriter_DefaultsPerPhase(t *testing.T) {
+	temperature := 0.2
+
+	writer := NewWriter(NewAnthropicProvider("test-api-key"), WriterConfig{
+		FirstDraft: GenerationParams{Model: "claude-haiku-4-5"},
+		CopyEdit:   GenerationParams{MaxTokens: 8000, Temperature: &temperature},
+	})
+
+	assert.Equal(t, "claude-haiku-4-5", writer.config.FirstDraft.Model)
+	assert.Equal(t, int64(DefaultMaxTokens), writer.config.FirstDraft.MaxTokens)
+	assert.Nil(t, writer.config.FirstDraft.Temperature)
+
+	assert.Equal(t, DefaultModel, writer.config.CopyEdit.Model)
+	assert.Equal(t, int64(8000), writer.config.CopyEdit.MaxTokens)
+}
+
+func TestNewWriter_ProviderDefaultModel(t *testing.T) {
+	writer := NewWriter(NewOpenAICompatibleProvider("", "", false), WriterConfig{})
+
+	assert.Equal(t, DefaultCompatibleModel, writer.config.FirstDraft.Model)
+	assert.Equal(t, DefaultCompatibleModel, writer.config.CopyEdit.Model)
+}
+
+func TestRevisionRequest(t *testing.T) {
+	request := revisionRequest("---\ntitle: Post\n---\n\nBody.", "  tighten the intro\n")
+
+	assert.Equal(t,
+		"<post>\n---\ntitle: Post\n---\n\nBody.\n</post>\n\n<feedback>\ntighten the intro\n</feedback>", request)
+}
+
+func TestReviseCopyEdit_EmptyFeedback(t *testing.T) {
+	writer := NewWriter(NewAnthropicProvider("test-api-key"), WriterConfig{})
+	_, err := writer.ReviseCopyEdit(context.Background(), "post", " ", ModeMemos, nil)
+
+	assert.ErrorContains(t, err, "feedback is empty")
+}
+
+func TestSplitTags(t *testing.T) {
+	index := NewTagIndex([]Post{{Frontmatter: Frontmatter{Tags: []string{"Go", "CLI Tools"}}}})
+	toolInput := &CopyEditToolInput{
+		Markdown:     "---\ntitle: \"Post\"\ntags: [\"go\", \"Rust\"]\n---\n\nBody.",
+		ExistingTags: []string{"go"},
+		NewTags:      []string{"Rust", "cli tools"},
+	}
+	result := &CopyEditResult{Markdown: toolInput.Markdown}
+
+	splitTags(result, toolInput, index)
+
+	assert.Equal(t, []string{"Go", "CLI Tools"}, result.ExistingTags)
+	assert.Equal(t, []string{"Rust"}, result.NewTags)
+	assert.Contains(t, result.Markdown, "tags: [\"Go\", \"CLI Tools\"]", "New tags stay out until accepted")
+
+	withRust, err := result.WithTags([]string{"Rust"})
+	require.NoError(t, err)
+	assert.Contains(t, withRust, "tags: [\"Go\", \"CLI Tools\", \"Rust\"]")
+}
+
+func TestSplitTags_NoTaxonomy(t *testing.T) {
+	toolInput := &CopyEditToolInput{Markdown: "---\ntitle: Post\n---\n", NewTags: []string{"Rust", "rust"}}
+	result := &CopyEditResult{Markdown: toolInput.Markdown}
+
+	splitTags(result, toolInput, nil)
+
+	assert.Equal(t, []string{"Rust"}, result.ExistingTags)
+	assert.Empty(t, result.NewTags)
+	assert.Equal(t, toolInput.Markdown, result.Markdown)
+}
+
+func TestMergeMetadata(t *testing.T) {
+	toolInput := &CopyEditToolInput{
+		Markdown:    "---\ntitle: \"Post\"\n---\n\nA short post.",
+		Description: "  A post about brevity. ",
+	}
+	result := &CopyEditResult{Markdown: toolInput.Markdown}
+
+	mergeMetadata(result, toolInput)
+
+	assert.Equal(t, PostMetadata{Description: "A post about brevity.", ReadingTime: 1}, result.Metadata)
+	assert.Equal(t,
+		"---\ntitle: \"Post\"\ndescription: \"A post about brevity.\"\nreadingTime: 1\n---\n\nA short post.",
+		result.Markdown)
+}
+
+func TestTitleCandidates(t *testing.T) {
+	toolInput := &CopyEditToolInput{
+		Title:           "Slow Start",
+		TitleCandidates: []string{"slow start", " Warming Up ", "", "Cold Engines", "Warming Up"},
+	}
+
+	assert.Equal(t, []string{"Slow Start", "Warming Up", "Cold Engines"}, titleCandidates(toolInput))
+}
+
+func TestCrossLinks(t *testing.T) {
+	related := []RelatedPost{
+		{Title: "Voice CLI Kickoff", Slug: "voice-cli-kickoff"},
+		{Title: "Start With Why", Slug: "start-with-why"},
+	}
+	toolInput := &CopyEditToolInput{CrossLinks: []CrossLinkToolInput{
+		{Slug: "voice-cli-kickoff", Text: "the kickoff"},
+		{Slug: "voice-cli-kickoff", Text: "first post"},
+		{Slug: "unknown", Text: "Body"},
+		{Slug: "start-with-why", Text: "not in the post"},
+	}}
+	markdown := "---\ntitle: \"Post\"\n---\n\nSince the kickoff and my first post, more to say."
+
+	links := crossLinks(toolInput, related, markdown)
+
+	require.Equal(t, []CrossLink{{Slug: "voice-cli-kickoff", Title: "Voice CLI Kickoff", Text: "the kickoff"}}, links,
+		"Links need a related post and text in the post, one per post")
+
+	result := (&CopyEditResult{Markdown: markdown, CrossLinks: links}).WithLinks(links)
+	assert.Contains(t, result.Markdown, "Since [the kickoff]({{< relref \"voice-cli-kickoff.md\" >}}) and")
+	assert.Equal(t, links, result.CrossLinks)
+}
+
+func TestParseSocialToolCall(t *testing.T) {
+	platforms := DefaultSocialPlatforms()
+
+	posts, err := parseSocialToolCall(&ChatResponse{ToolCall: &ToolCall{
+		Name:  "save_social_posts",
+		Input: []byte(`{"linkedin": " A longer post. ", "threads": "A short one."}`),
+	}}, platforms)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, "LinkedIn", posts[0].Platform.Label)
+	assert.Equal(t, "A longer post.", posts[0].Text)
+	assert.Equal(t, "A short one.", posts[1].Text)
+
+	_, err = parseSocialToolCall(&ChatResponse{ToolCall: &ToolCall{
+		Name:  "save_social_posts",
+		Input: []byte(`{"linkedin": "A longer post."}`),
+	}}, platforms)
+	assert.ErrorContains(t, err, "Threads")
+}
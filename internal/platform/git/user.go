@@ -0,0 +1,26 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GetUserName returns the configured git user.name.
+// Returns an error if git is unavailable or no name is set.
+func GetUserName() (string, error) {
+	ctx := context.Background()
+	cmd := exec.CommandContext(ctx, "git", "config", "user.name")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get git user name: %w", err)
+	}
+
+	name := strings.TrimSpace(string(output))
+	if name == "" {
+		return "", fmt.Errorf("git user name is empty")
+	}
+
+	return name, nil
+}
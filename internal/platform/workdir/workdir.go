@@ -11,6 +11,33 @@ const (
 	MP3File        = "recording.mp3"
 	TranscriptFile = "transcript.txt"
 	FirstDraftFile = "first-draft.md"
+	SegmentsDir    = "segments"
+	// RevisionsDir keeps every copy edit revision of the post.
+	RevisionsDir = "revisions"
+
+	// OriginalTranscriptFile keeps the source-language transcript when translating.
+	OriginalTranscriptFile = "transcript.original.txt"
+	// SourceLanguageFile records the spoken language of a translated transcript.
+	SourceLanguageFile = "source-language.txt"
+	// PostPathFile records where the copy-edited post was saved.
+	PostPathFile = "post-path.txt"
+)
+
+const (
+	// ConfigFile holds flag defaults as JSON, keyed by snake_case flag name.
+	ConfigFile = "config.json"
+	// PromptsDir holds prompt templates overriding the built-in ones.
+	PromptsDir = "prompts"
+	// GlossaryFile holds user vocabulary for transcription, one term per line.
+	GlossaryFile = "glossary.txt"
+	// RedactNamesFile lists names to redact before text reaches the LLM, one
+	// per line.
+	RedactNamesFile = "redact-names.txt"
+	// TranscriptCacheDir holds transcripts keyed by audio content hash.
+	TranscriptCacheDir = "cache/transcripts"
+	// UsageLedgerFile records the tokens and audio of every API request, one
+	// JSON object per line.
+	UsageLedgerFile = "usage.jsonl"
 )
 
 // Root returns the base directory for all voice CLI working files.
@@ -25,6 +52,15 @@ func Root() (string, error) {
 	return filepath.Join(home, "Documents", "Alkime", "Memos"), nil
 }
 
+// RootFilePath returns the full path for a file directly under Root.
+func RootFilePath(filename string) (string, error) {
+	root, err := Root()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, filename), nil
+}
+
 // WorkPath returns the full path for a working directory with the given name.
 // The name typically corresponds to a git branch or user-specified identifier.
 func WorkPath(workingName string) (string, error) {
@@ -16,6 +16,12 @@ type PrevPhaseMsg struct{}
 // PrevPhaseCmd is a command that returns a PrevPhaseMsg.
 var PrevPhaseCmd = func() tea.Msg { return PrevPhaseMsg{} }
 
+// InputCapturer is implemented by phase models that sometimes take free text
+// input, during which single-key shortcuts (like q to quit) must not fire.
+type InputCapturer interface {
+	CapturingInput() bool
+}
+
 type Phase struct {
 	Name string
 	mdl  tea.Model
@@ -90,6 +96,20 @@ func (m Model) View() string {
 	return m.currentPhase().View()
 }
 
+// OnLastPhase reports whether the current phase is the final one.
+func (m Model) OnLastPhase() bool {
+	return m.curr == len(m.phases)-1
+}
+
+// CapturingInput reports whether the current phase is taking text input.
+func (m Model) CapturingInput() bool {
+	if ic, ok := m.currentPhase().mdl.(InputCapturer); ok {
+		return ic.CapturingInput()
+	}
+
+	return false
+}
+
 // CurrentPhaseName returns the name of the current phase.
 func (m Model) CurrentPhaseName() string {
 	return m.currentPhase().Name
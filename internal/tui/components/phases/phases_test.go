@@ -144,3 +144,40 @@ func (o outputChecker) CheckString(t *testing.T, tm *teatest.TestModel, substr s
 type mockMsg struct {
 	triggerForward bool
 }
+
+// capturingMock is a phase model that reports whether it is taking text input.
+type capturingMock struct {
+	modelMock
+	capturing bool
+}
+
+func (m *capturingMock) CapturingInput() bool { return m.capturing }
+
+func TestPhases_CapturingInput(t *testing.T) {
+	capturing := &capturingMock{modelMock: modelMock{t: t, name: "editor"}, capturing: true}
+
+	ph := phases.New([]phases.Phase{
+		phases.NewPhase("plain", &modelMock{t: t, name: "plain"}),
+		phases.NewPhase("editor", capturing),
+	})
+	require.False(t, ph.CapturingInput(), "phases without InputCapturer never capture")
+
+	updated, _ := ph.Update(phases.NextPhaseMsg{})
+	ph = updated.(phases.Model) //nolint:forcetypeassert // phases.Model always returns phases.Model
+	require.True(t, ph.CapturingInput())
+
+	capturing.capturing = false
+	require.False(t, ph.CapturingInput())
+}
+
+func TestPhases_OnLastPhase(t *testing.T) {
+	ph := phases.New([]phases.Phase{
+		phases.NewPhase("first", &modelMock{t: t, name: "first"}),
+		phases.NewPhase("last", &modelMock{t: t, name: "last"}),
+	})
+	require.False(t, ph.OnLastPhase())
+
+	updated, _ := ph.Update(phases.NextPhaseMsg{})
+	ph = updated.(phases.Model) //nolint:forcetypeassert // phases.Model always returns phases.Model
+	require.True(t, ph.OnLastPhase())
+}
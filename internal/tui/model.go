@@ -15,14 +15,36 @@ import (
 
 // Config holds TUI configuration.
 type Config struct {
-	Cancel          context.CancelFunc
-	WorkingName     string
-	OpenAIAPIKey    string
-	AnthropicAPIKey string
-	Mode            content.Mode
-	MaxBytes        int64
-	EditorCmd       string
-	OutputDir       string
+	Cancel       context.CancelFunc
+	WorkingName  string
+	OpenAIAPIKey string
+	MaxBytes     int64
+	EditorCmd    string
+	OutputDir    string
+
+	// Provider sends the drafting and copy-editing requests.
+	Provider content.Provider
+
+	// Mode decides the prompts, output file name and which optional phases run.
+	Mode content.ModeConfig
+	// Tags is the blog's tag taxonomy; copy edits propose other tags as new.
+	Tags *content.TagIndex
+
+	TranscriptionHints content.TranscriptionHints
+	// TranscriptCacheDir enables the transcription cache when non-empty.
+	TranscriptCacheDir string
+	// Translate adds a phase translating the transcript into English.
+	Translate bool
+	// Social, when non-empty, adds a phase after Copy Edit writing a post
+	// sharing the blog post on each platform.
+	Social []content.SocialPlatform
+	// Generation sets model and sampling parameters per Writer phase.
+	Generation content.WriterConfig
+	// Usage, when set, records the tokens and audio of every request.
+	Usage content.UsageRecorder
+	// Offline replaces Whisper and the LLM with deterministic stand-ins, so
+	// no keys or network are needed.
+	Offline bool
 }
 
 // model is the TUI model using the phases component.
@@ -35,47 +57,101 @@ type model struct {
 }
 
 // New creates a new TUI model using the phases component.
-func New(config Config, recordingControls workflow.RecordingControls) tea.Model {
+// Network requests made by the phases are cancelled when ctx is done.
+//
+//nolint:funlen // Wires every workflow phase in order
+func New(ctx context.Context, config Config, recordingControls workflow.RecordingControls) tea.Model {
 	// Create service clients
-	transcriber := content.NewTranscriber(config.OpenAIAPIKey)
-	writer := content.NewWriter(config.AnthropicAPIKey)
+	transcriber, recordingTranscriber, writer := newClients(config)
 	editorLauncher := &workflow.DefaultEditorLauncher{EditorCmd: config.EditorCmd}
 
+	live := workflow.NewLiveTranscript(transcriber)
+	live.Start(ctx, recordingControls.Segments)
+
+	// When translating, the Whisper transcript is kept in the original language
+	// and the English translation takes its place for the later phases
+	transcribedPath := workdir.MustFilePath(config.WorkingName, workdir.TranscriptFile)
+	sourceLanguagePath := ""
+	if config.Translate {
+		transcribedPath = workdir.MustFilePath(config.WorkingName, workdir.OriginalTranscriptFile)
+		sourceLanguagePath = workdir.MustFilePath(config.WorkingName, workdir.SourceLanguageFile)
+	}
+
 	var phs []phases.Phase
 
 	phs = append(phs, phases.NewPhase("Recording", workflow.NewRecording(
 		recordingControls,
+		live,
 		config.MaxBytes,
 		workdir.MustFilePath(config.WorkingName, workdir.MP3File),
 	)))
 	phs = append(phs, phases.NewPhase("Transcribing", workflow.NewTranscribePhase(
-		transcriber,
+		ctx,
+		recordingTranscriber,
+		live,
 		workdir.MustFilePath(config.WorkingName, workdir.MP3File),
-		workdir.MustFilePath(config.WorkingName, workdir.TranscriptFile),
+		transcribedPath,
 	)))
 
-	phs = append(phs, phases.NewPhase("View Transcript", workflow.NewViewTranscriptPhase(
-		workdir.MustFilePath(config.WorkingName, workdir.TranscriptFile),
-	)))
+	if config.Translate {
+		phs = append(phs, phases.NewPhase("Translate", workflow.NewTranslatePhase(
+			ctx,
+			writer,
+			transcribedPath,
+			workdir.MustFilePath(config.WorkingName, workdir.TranscriptFile),
+			sourceLanguagePath,
+		)))
+	}
 
-	phs = append(phs, phases.NewPhase("First Draft", workflow.NewFirstDraftPhase(
-		writer,
-		workdir.MustFilePath(config.WorkingName, workdir.TranscriptFile),
-		workdir.MustFilePath(config.WorkingName, workdir.FirstDraftFile),
-		config.Mode,
-	)))
+	if config.Mode.Runs(content.PhaseViewTranscript) {
+		phs = append(phs, phases.NewPhase("View Transcript", workflow.NewViewTranscriptPhase(
+			editorLauncher,
+			workdir.MustFilePath(config.WorkingName, workdir.TranscriptFile),
+		)))
+	}
 
-	phs = append(phs, phases.NewPhase("Edit Draft", workflow.NewEditDraftPhase(
-		editorLauncher,
-		workdir.MustFilePath(config.WorkingName, workdir.FirstDraftFile),
-	)))
+	if config.Mode.Runs(content.PhaseFirstDraft) {
+		phs = append(phs, phases.NewPhase("First Draft", workflow.NewFirstDraftPhase(
+			ctx,
+			writer,
+			workdir.MustFilePath(config.WorkingName, workdir.TranscriptFile),
+			workdir.MustFilePath(config.WorkingName, workdir.FirstDraftFile),
+			sourceLanguagePath,
+			config.Mode.Name,
+		)))
+	}
 
-	phs = append(phs, phases.NewPhase("Copy Edit", workflow.NewCopyEditPhase(
-		writer,
-		workdir.MustFilePath(config.WorkingName, workdir.FirstDraftFile),
-		config.Mode,
-		config.OutputDir,
-	)))
+	if config.Mode.Runs(content.PhaseEditDraft) {
+		phs = append(phs, phases.NewPhase("Edit Draft", workflow.NewEditDraftPhase(
+			editorLauncher,
+			workdir.MustFilePath(config.WorkingName, workdir.FirstDraftFile),
+		)))
+	}
+
+	if config.Mode.Runs(content.PhaseCopyEdit) {
+		phs = append(phs, phases.NewPhase("Copy Edit", workflow.NewCopyEditPhase(
+			ctx,
+			writer,
+			workdir.MustFilePath(config.WorkingName, workdir.FirstDraftFile),
+			config.Mode,
+			config.Tags,
+			workflow.CopyEditFiles{
+				OutputDir:    config.OutputDir,
+				RevisionsDir: workdir.MustFilePath(config.WorkingName, workdir.RevisionsDir),
+				PostPathFile: workdir.MustFilePath(config.WorkingName, workdir.PostPathFile),
+			},
+		)))
+
+		if len(config.Social) > 0 {
+			phs = append(phs, phases.NewPhase("Social", workflow.NewSocialPhase(
+				ctx,
+				writer,
+				workdir.MustFilePath(config.WorkingName, workdir.PostPathFile),
+				config.Social,
+				workflow.OSC52Clipboard{},
+			)))
+		}
+	}
 
 	return &model{
 		config:       config,
@@ -86,6 +162,30 @@ func New(config Config, recordingControls workflow.RecordingControls) tea.Model
 	}
 }
 
+// newClients creates the transcriber for live segments, the one for whole
+// recordings and the writer, or offline stand-ins for all of them.
+func newClients(config Config) (live, recording workflow.Transcriber, writer workflow.Writer) {
+	generation := config.Generation
+	generation.Usage = config.Usage
+
+	if config.Offline {
+		// One script is split between the live segments and the recording
+		transcriber := content.NewOfflineTranscriber()
+
+		return transcriber.Segments(), transcriber, content.NewOfflineWriter(generation)
+	}
+
+	transcriber := content.NewTranscriber(config.OpenAIAPIKey, config.TranscriptionHints, config.Usage)
+
+	// Whole-recording transcriptions are cached; live segments are one-offs
+	recording = transcriber
+	if config.TranscriptCacheDir != "" {
+		recording = content.NewCachedTranscriber(transcriber, content.NewTranscriptCache(config.TranscriptCacheDir))
+	}
+
+	return transcriber, recording, content.NewWriter(config.Provider, generation)
+}
+
 // Init returns the initial command.
 func (m *model) Init() tea.Cmd {
 	return m.phases.Init()
@@ -109,7 +209,7 @@ func (m *model) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return m, tea.Quit
 
-		case key.Matches(km, m.keys.Quit):
+		case key.Matches(km, m.keys.Quit) && !m.phases.CapturingInput():
 			if m.config.Cancel != nil {
 				m.config.Cancel()
 			}
@@ -118,6 +218,15 @@ func (m *model) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// Modes may end before Copy Edit or Social; finishing the last phase ends the workflow
+	if _, ok := teaMsg.(phases.NextPhaseMsg); ok && m.phases.OnLastPhase() {
+		if m.config.Cancel != nil {
+			m.config.Cancel()
+		}
+
+		return m, tea.Quit
+	}
+
 	// Delegate to phases container
 	updatedPhases, cmd := m.phases.Update(teaMsg)
 	m.phases = updatedPhases.(phases.Model) //nolint:forcetypeassert // phases.Model always returns phases.Model
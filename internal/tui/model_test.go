@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/alkime/memos/internal/platform/workdir"
+	"github.com/alkime/memos/internal/tui/workflow"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubKnob is a recording toggle without an audio device.
+type stubKnob struct{ on bool }
+
+func (k *stubKnob) Read() bool { return k.on }
+func (k *stubKnob) On()        { k.on = true }
+func (k *stubKnob) Off()       { k.on = false }
+func (k *stubKnob) Toggle()    { k.on = !k.on }
+
+// stubDial is a file size that never grows.
+type stubDial struct{}
+
+func (stubDial) Read() int64                { return 0 }
+func (stubDial) Cap() (num, maxValue int64) { return 0, 0 }
+
+// stubLevels is a silent waveform.
+type stubLevels struct{}
+
+func (stubLevels) Read() []int16 { return nil }
+
+func TestOffline_RecordingToPost(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.Ascii)
+	t.Setenv("HOME", t.TempDir())
+
+	const workingName = "offline-test"
+	workPath, err := workdir.WorkPath(workingName)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(workPath, 0o755))
+	outputDir := t.TempDir()
+
+	// Two segments are cut at pauses while recording; the tail follows when
+	// the recording is finished
+	segments := make(chan workflow.SpeechSegment, 3)
+	for _, name := range []string{"segment-000.wav", "segment-001.wav", "tail.wav"} {
+		require.NoError(t, os.WriteFile(filepath.Join(workPath, name), []byte("RIFF....WAVE"), 0o600))
+	}
+	segments <- workflow.SpeechSegment{Path: filepath.Join(workPath, "segment-000.wav")}
+	segments <- workflow.SpeechSegment{Path: filepath.Join(workPath, "segment-001.wav")}
+
+	var tm *teatest.TestModel
+	controls := workflow.RecordingControls{
+		FileSize:       stubDial{},
+		StartStopPause: &stubKnob{on: true},
+		SampleLevels:   stubLevels{},
+		Segments:       segments,
+		Finish: func() {
+			segments <- workflow.SpeechSegment{Path: filepath.Join(workPath, "tail.wav"), Final: true}
+			close(segments)
+			go tm.Send(workflow.AudioFinalizingCompleteMsg{})
+		},
+	}
+
+	mode := content.DefaultModes()[content.ModeMemos]
+	mode.Phases = []string{content.PhaseFirstDraft, content.PhaseCopyEdit}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	model := New(ctx, Config{
+		Cancel:      cancel,
+		WorkingName: workingName,
+		OutputDir:   outputDir,
+		Mode:        mode,
+		Generation: content.WriterConfig{
+			PromptData: content.PromptData{Author: "Sam"},
+		},
+		Offline: true,
+	}, controls)
+
+	tm = teatest.NewTestModel(t, model, teatest.WithInitialTermSize(100, 60))
+
+	waitFor := func(text string) {
+		t.Helper()
+		teatest.WaitFor(t, tm.Output(), func(out []byte) bool {
+			return bytes.Contains(out, []byte(text))
+		}, teatest.WithDuration(5*time.Second), teatest.WithCheckInterval(20*time.Millisecond))
+	}
+
+	waitFor("Phase: Recording")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	waitFor("Copy Edit Complete")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	tm.WaitFinished(t, teatest.WithFinalTimeout(5*time.Second))
+
+	// However the preview was cut, the transcript is the whole script
+	script, err := content.NewOfflineTranscriber().TranscribeFile(context.Background(), bytes.NewReader(nil))
+	require.NoError(t, err)
+	transcript, err := os.ReadFile(filepath.Join(workPath, workdir.TranscriptFile))
+	require.NoError(t, err)
+	assert.Equal(t, script, string(transcript))
+
+	postPath, err := os.ReadFile(filepath.Join(workPath, workdir.PostPathFile))
+	require.NoError(t, err)
+	post, err := os.ReadFile(string(bytes.TrimSpace(postPath)))
+	require.NoError(t, err)
+	assert.Contains(t, string(post), "# So I've Been Building A Little\n\nSo I've been building")
+	assert.Contains(t, string(post), "Next up I want better tags and links between posts.")
+	assert.Contains(t, string(post), "{{< byline >}}")
+}
@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -10,67 +11,221 @@ import (
 
 	"github.com/alkime/memos/internal/content"
 	"github.com/alkime/memos/internal/tui/components/labeledspinner"
+	"github.com/alkime/memos/internal/tui/components/phases"
 	"github.com/alkime/memos/internal/tui/style"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+type copyEditKeyMap struct {
+	Accept  key.Binding
+	Revise  key.Binding
+	Confirm key.Binding
+	Cancel  key.Binding
+}
+
+func defaultCopyEditKeyMap() copyEditKeyMap {
+	return copyEditKeyMap{
+		Accept: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "accept"),
+		),
+		Revise: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "request revision"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "send"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+// copyEditState tracks where the phase is in the copy edit and revision loop.
+type copyEditState int
+
+const (
+	copyEditGenerating copyEditState = iota
+	copyEditReviewing
+	copyEditFeedback
+	copyEditRevising
+)
+
 type copyEditPhase struct {
-	spinner   labeledspinner.Model
-	inputPath string
-	mode      content.Mode
-	client    Writer
-	outputDir string
-
-	// Completion state
-	complete   bool
+	ctx          context.Context
+	spinner      labeledspinner.Model
+	inputPath    string
+	mode         content.ModeConfig
+	tags         *content.TagIndex
+	client       Writer
+	outputDir    string
+	revisionsDir string
+	postPathFile string
+	retries      *retryWatcher
+	keys         copyEditKeyMap
+	state        copyEditState
+
+	// Latest revision
+	revision   int
 	outputPath string
-	changes    []string
-	title      string
+	result     *content.CopyEditResult
+	// markdown is the post under the chosen title with the approved links and
+	// accepted new tags
+	markdown string
+	changes  []string
+	title    string
+	// source is the first draft the post was copy edited from
+	source string
+	// problems lists what failed content.ValidateCopyEdit
+	problems []string
+	// unsaved is set when the title, approved links or accepted tags changed
+	// since the post was written
+	unsaved bool
+	// revisionErr is why the last revision failed, shown until the next one
+	revisionErr error
+
+	titles titlePicker
+	links  linkPicker
+	picker tagPicker
+
+	feedback textinput.Model
+	viewport viewport.Model
+	height   int
+}
+
+// CopyEditFiles says where the copy edit phase writes.
+type CopyEditFiles struct {
+	// OutputDir holds the post, named by the mode's filename pattern.
+	OutputDir string
+	// RevisionsDir, when set, keeps a copy of every revision.
+	RevisionsDir string
+	// PostPathFile, when set, receives the post's path for later phases.
+	PostPathFile string
 }
 
-// NewCopyEditPhase creates a new copy edit phase.
-func NewCopyEditPhase(writer Writer, inputPath string, mode content.Mode, outputDir string) tea.Model {
+// NewCopyEditPhase creates a phase that copy edits the first draft at
+// inputPath and revises it on feedback until accepted. Requests are
+// cancelled when ctx is done.
+func NewCopyEditPhase(
+	ctx context.Context,
+	writer Writer,
+	inputPath string,
+	mode content.ModeConfig,
+	tags *content.TagIndex,
+	files CopyEditFiles,
+) tea.Model {
+	feedback := textinput.New()
+	feedback.Prompt = "Feedback: "
+	feedback.Placeholder = "tighten the intro"
+
 	return &copyEditPhase{
+		ctx: ctx,
 		spinner: labeledspinner.New(
 			spinner.Pulse,
 			"Copy editing draft...",
-			"Claude is polishing your post",
+			"The model is polishing your post",
 			"This may take a moment",
 		),
-		inputPath: inputPath,
-		mode:      mode,
-		client:    writer,
-		outputDir: outputDir,
+		inputPath:    inputPath,
+		mode:         mode,
+		tags:         tags,
+		client:       writer,
+		outputDir:    files.OutputDir,
+		revisionsDir: files.RevisionsDir,
+		postPathFile: files.PostPathFile,
+		keys:         defaultCopyEditKeyMap(),
+		titles:       newTitlePicker(),
+		links:        newLinkPicker(),
+		picker:       newTagPicker(),
+		feedback:     feedback,
+		viewport:     viewport.New(76, 10),
+		height:       24,
 	}
 }
 
 type copyEditCompleteMsg struct {
+	result     *content.CopyEditResult
+	markdown   string
+	source     string
 	outputPath string
-	changes    []string
-	title      string
+	problems   []string
+}
+
+// copyEditFailedMsg reports a failed revision, leaving the latest revision
+// to be accepted or revised again.
+type copyEditFailedMsg struct {
+	err error
 }
 
 func (cp *copyEditPhase) Init() tea.Cmd {
 	return tea.Sequence(
+		tea.WindowSize(),
 		cp.spinner.Init(),
 		cp.copyEditCmd(),
 	)
 }
 
+// CapturingInput reports whether revision feedback is being typed.
+func (cp *copyEditPhase) CapturingInput() bool {
+	return cp.state == copyEditFeedback
+}
+
 func (cp *copyEditPhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := teaMsg.(type) {
+	case tea.WindowSizeMsg:
+		cp.viewport.Width = max(msg.Width-4, 10)
+		cp.height = msg.Height
+		cp.resizeViewport()
+
+		return cp, nil
+
 	case copyEditCompleteMsg:
-		cp.complete = true
+		cp.state = copyEditReviewing
+		cp.revision++
 		cp.outputPath = msg.outputPath
-		cp.changes = msg.changes
-		cp.title = msg.title
+		cp.result = msg.result
+		cp.markdown = msg.markdown
+		cp.changes = msg.result.Changes
+		cp.title = msg.result.Title
+		cp.source = msg.source
+		cp.problems = msg.problems
+		cp.unsaved = false
+		cp.revisionErr = nil
+		cp.titles.setTitles(msg.result.TitleCandidates)
+		cp.links.setLinks(msg.result.CrossLinks)
+		cp.picker.setTags(msg.result.NewTags)
+		cp.viewport.SetContent(wrapText(cp.markdown, cp.viewport.Width))
+		cp.viewport.GotoTop()
+		cp.resizeViewport()
 
 		return cp, nil
+
+	case copyEditFailedMsg:
+		cp.state = copyEditReviewing
+		cp.revisionErr = msg.err
+		cp.resizeViewport()
+
+		return cp, nil
+
+	case copyEditSavedMsg:
+		return cp, phases.NextPhaseCmd
+
+	case retryMsg:
+		return cp, cp.retries.update(msg)
+
+	case tea.KeyMsg:
+		return cp.handleKeyMsg(msg)
 	}
 
-	// Only update spinner if not complete
-	if !cp.complete {
+	// Only update spinner while a request is running
+	if cp.state == copyEditGenerating || cp.state == copyEditRevising {
 		var cmd tea.Cmd
 		cp.spinner, cmd = cp.spinner.Update(teaMsg)
 
@@ -80,9 +235,77 @@ func (cp *copyEditPhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 	return cp, nil
 }
 
+func (cp *copyEditPhase) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch cp.state {
+	case copyEditReviewing:
+		for _, update := range []func(tea.KeyMsg) (bool, bool){cp.titles.update, cp.links.update, cp.picker.update} {
+			if handled, changed := update(msg); handled {
+				if changed {
+					cp.applyChoices()
+				}
+
+				return cp, nil
+			}
+		}
+
+		switch {
+		case key.Matches(msg, cp.keys.Accept):
+			if cp.unsaved {
+				return cp, cp.saveChoicesCmd()
+			}
+
+			return cp, phases.NextPhaseCmd
+		case key.Matches(msg, cp.keys.Revise):
+			cp.state = copyEditFeedback
+			cp.feedback.Reset()
+			cp.resizeViewport()
+
+			return cp, cp.feedback.Focus()
+		}
+
+		var cmd tea.Cmd
+		cp.viewport, cmd = cp.viewport.Update(msg)
+
+		return cp, cmd
+
+	case copyEditFeedback:
+		switch {
+		case key.Matches(msg, cp.keys.Cancel):
+			cp.state = copyEditReviewing
+			cp.feedback.Blur()
+			cp.resizeViewport()
+
+			return cp, nil
+
+		case key.Matches(msg, cp.keys.Confirm):
+			feedback := strings.TrimSpace(cp.feedback.Value())
+			if feedback == "" {
+				return cp, nil
+			}
+			cp.state = copyEditRevising
+			cp.feedback.Blur()
+
+			return cp, tea.Sequence(cp.spinner.Init(), cp.reviseCmd(feedback))
+		}
+
+		var cmd tea.Cmd
+		cp.feedback, cmd = cp.feedback.Update(msg)
+
+		return cp, cmd
+
+	case copyEditGenerating, copyEditRevising:
+	}
+
+	return cp, nil
+}
+
 func (cp *copyEditPhase) View() string {
-	if !cp.complete {
-		return cp.spinner.View()
+	switch cp.state {
+	case copyEditGenerating:
+		return cp.spinner.ViewWithHelp(cp.retries.helpOr(cp.spinner.Help))
+	case copyEditRevising:
+		return cp.spinner.ViewWithHelp(cp.retries.helpOr("Applying your feedback"))
+	case copyEditReviewing, copyEditFeedback:
 	}
 
 	return cp.completeView()
@@ -92,7 +315,11 @@ func (cp *copyEditPhase) completeView() string {
 	var sb strings.Builder
 
 	// Header
-	sb.WriteString(style.Title.Render("=== Copy Edit Complete ==="))
+	header := "=== Copy Edit Complete ==="
+	if cp.revision > 1 {
+		header = fmt.Sprintf("=== Revision %d ===", cp.revision)
+	}
+	sb.WriteString(style.Title.Render(header))
 	sb.WriteString("\n\n")
 
 	// Title and output path
@@ -103,6 +330,19 @@ func (cp *copyEditPhase) completeView() string {
 	sb.WriteString(style.Muted.Render(cp.outputPath))
 	sb.WriteString("\n\n")
 
+	if cp.revisionErr != nil {
+		sb.WriteString(style.Error.Render("Revision failed: " + cp.revisionErr.Error()))
+		sb.WriteString("\n\n")
+	}
+
+	if len(cp.problems) > 0 {
+		for _, problem := range cp.problems {
+			sb.WriteString(style.Warning.Render(problem))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
 	// Changes list
 	sb.WriteString(style.Label.Render("Changes:"))
 	sb.WriteString("\n")
@@ -114,48 +354,255 @@ func (cp *copyEditPhase) completeView() string {
 	}
 	sb.WriteString("\n")
 
-	// Help text (only global quit keys)
+	if cp.state == copyEditReviewing {
+		sb.WriteString(cp.titles.view())
+		sb.WriteString(cp.links.view())
+		sb.WriteString(cp.picker.view())
+	}
+
+	// Revised post
+	sb.WriteString(style.Viewport.Render(cp.viewport.View()))
+	sb.WriteString("\n\n")
+
+	if cp.state == copyEditFeedback {
+		sb.WriteString(cp.feedback.View())
+		sb.WriteString("\n")
+		sb.WriteString(renderKeyHelp(cp.keys.Confirm, " "))
+		sb.WriteString(renderKeyHelp(cp.keys.Cancel, "\n"))
+
+		return sb.String()
+	}
+
+	sb.WriteString(renderKeyHelp(cp.keys.Accept, " "))
+	sb.WriteString(renderKeyHelp(cp.keys.Revise, "\n"))
 	sb.WriteString(renderGlobalKeyHelp())
 
 	return sb.String()
 }
 
+// resizeViewport fits the post preview below the header and changes list.
+func (cp *copyEditPhase) resizeViewport() {
+	// Root phase header (2), title/saved (3), changes header and padding (3),
+	// viewport border (2) and key help (3), plus the feedback line
+	used := 13 + len(cp.changes)
+	if len(cp.problems) > 0 {
+		used += len(cp.problems) + 1
+	}
+	if cp.revisionErr != nil {
+		used += 2
+	}
+	if cp.state == copyEditFeedback {
+		used++
+	} else {
+		used += cp.titles.height() + cp.links.height() + cp.picker.height()
+	}
+
+	cp.viewport.Height = max(cp.height-used, 5)
+}
+
 func (cp *copyEditPhase) copyEditCmd() tea.Cmd {
-	return func() tea.Msg {
+	failure := "Copy edit generation failed"
+
+	return cp.requestCmd(failure, func(ctx context.Context) (string, *content.CopyEditResult, error) {
 		// Read user-edited first draft
 		draftContent, err := os.ReadFile(cp.inputPath)
 		if err != nil {
-			slog.Error("Failed to read first draft file", "error", err)
-			return tea.Quit
+			return "", nil, fmt.Errorf("failed to read first draft file: %w", err)
 		}
+		draft := string(draftContent)
 
-		// Generate copy edit via Claude API
+		// Generate copy edit via the LLM provider
 		currentDate := time.Now().Format("2006-01-02")
-		result, err := cp.client.GenerateCopyEdit(string(draftContent), currentDate, cp.mode)
+		result, err := cp.client.GenerateCopyEdit(ctx, draft, currentDate, cp.mode.Name, cp.tags)
+
+		return draft, result, err
+	})
+}
+
+func (cp *copyEditPhase) reviseCmd(feedback string) tea.Cmd {
+	markdown := cp.markdown
+	source := cp.source
+
+	return cp.requestCmd("Revision failed", func(ctx context.Context) (string, *content.CopyEditResult, error) {
+		result, err := cp.client.ReviseCopyEdit(ctx, markdown, feedback, cp.mode.Name, cp.tags)
+
+		return source, result, err
+	})
+}
+
+// requestCmd runs a copy edit or revision request and saves the result.
+// request returns the first draft the post is checked against.
+func (cp *copyEditPhase) requestCmd(
+	failure string,
+	request func(ctx context.Context) (string, *content.CopyEditResult, error),
+) tea.Cmd {
+	retries := newRetryWatcher()
+	cp.retries = retries
+
+	previousPath := cp.outputPath
+	revision := cp.revision + 1
+	// A failed revision leaves the previous one in place, while without a
+	// post there is nothing left to do
+	revising := cp.state == copyEditRevising
+	// Decisions on links and new tags carry over to those the result
+	// suggests again
+	links := cp.links
+	picker := cp.picker
+
+	return tea.Batch(retries.waitCmd(), func() tea.Msg {
+		defer retries.done()
+
+		ctx, cancel := context.WithTimeout(retries.context(cp.ctx), copyEditTimeout)
+		defer cancel()
+
+		source, result, err := request(ctx)
 		if err != nil {
-			slog.Error("Copy edit generation failed", "error", err)
-			return tea.Quit
+			logRequestError(failure, err)
+			if revising {
+				return copyEditFailedMsg{err: err}
+			}
+
+			return tea.Quit()
 		}
 
-		// Generate output path: {outputDir}/{YYYY-MM}-{slug}.md
-		slug := content.GenerateSlug(result.Title)
-		datePrefix := time.Now().Format("2006-01")
-		filename := fmt.Sprintf("%s-%s.md", datePrefix, slug)
-		outputPath := filepath.Join(cp.outputDir, filename)
-
-		// Write the final post
-		//nolint:gosec // Blog posts need to be readable
-		if err := os.WriteFile(outputPath, []byte(result.Markdown), 0o644); err != nil {
-			slog.Error("Failed to write copy-edited post", "error", err, "path", outputPath)
-			return tea.Quit
+		links.setLinks(result.CrossLinks)
+		picker.setTags(result.NewTags)
+		markdown := withAcceptedTags(result.WithLinks(links.approvedLinks()), picker.acceptedTags())
+
+		outputPath, err := cp.save(result.Title, markdown, previousPath, revision)
+		if err != nil {
+			slog.Error("Failed to save copy-edited post", "error", err)
+			return tea.Quit()
 		}
 
-		slog.Info("Copy edit complete", "output", outputPath, "title", result.Title)
+		problems := content.ValidateCopyEdit(source, markdown, cp.mode)
+		if len(problems) > 0 {
+			slog.Warn("Copy-edited post failed validation", "mode", cp.mode.Name, "problems", problems)
+		}
 
 		return copyEditCompleteMsg{
+			result:     result,
+			markdown:   markdown,
+			source:     source,
 			outputPath: outputPath,
-			changes:    result.Changes,
-			title:      result.Title,
+			problems:   problems,
+		}
+	})
+}
+
+// applyChoices updates the post after another title was chosen or a link or
+// new tag was accepted or rejected.
+func (cp *copyEditPhase) applyChoices() {
+	post := withTitle(cp.result, cp.titles.title()).WithLinks(cp.links.approvedLinks())
+	cp.title = post.Title
+	cp.markdown = withAcceptedTags(post, cp.picker.acceptedTags())
+	cp.problems = content.ValidateCopyEdit(cp.source, cp.markdown, cp.mode)
+	cp.unsaved = true
+	cp.viewport.SetContent(wrapText(cp.markdown, cp.viewport.Width))
+	cp.resizeViewport()
+}
+
+// withAcceptedTags returns the result's markdown with the accepted new tags.
+// If the frontmatter cannot be updated, the tags are left out.
+func withAcceptedTags(result *content.CopyEditResult, accepted []string) string {
+	markdown, err := result.WithTags(accepted)
+	if err != nil {
+		slog.Warn("Failed to add accepted tags", "error", err, "tags", accepted)
+		return result.Markdown
+	}
+
+	return markdown
+}
+
+// withTitle returns the result under the chosen title. If the markdown cannot
+// be updated, the copy editor's title is kept.
+func withTitle(result *content.CopyEditResult, title string) *content.CopyEditResult {
+	if title == "" {
+		return result
+	}
+
+	post, err := result.WithTitle(title)
+	if err != nil {
+		slog.Warn("Failed to change title", "error", err, "title", title)
+		return result
+	}
+
+	return post
+}
+
+type copyEditSavedMsg struct{}
+
+// saveChoicesCmd rewrites the accepted post with the chosen title, links and
+// tags, renaming it when the title changed.
+func (cp *copyEditPhase) saveChoicesCmd() tea.Cmd {
+	title := cp.title
+	markdown := cp.markdown
+	previousPath := cp.outputPath
+	revision := cp.revision
+
+	return func() tea.Msg {
+		if _, err := cp.save(title, markdown, previousPath, revision); err != nil {
+			slog.Error("Failed to save accepted post", "error", err)
+			return tea.Quit()
+		}
+
+		return copyEditSavedMsg{}
+	}
+}
+
+// save writes the post under a name from the mode's pattern, removing the
+// previous revision's file if a new title renamed it, and keeps a copy of the
+// revision. Returns the post's path.
+func (cp *copyEditPhase) save(title, markdown, previousPath string, revision int) (string, error) {
+	// Generate output path from the mode's pattern, e.g. {outputDir}/{YYYY-MM}-{slug}.md
+	filename, err := cp.mode.OutputFilename(title, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to name copy-edited post: %w", err)
+	}
+	outputPath := filepath.Join(cp.outputDir, filename)
+
+	// Write the final post
+	//nolint:gosec // Blog posts need to be readable
+	if err := os.WriteFile(outputPath, []byte(markdown), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write copy-edited post %s: %w", outputPath, err)
+	}
+
+	if previousPath != "" && previousPath != outputPath {
+		if err := os.Remove(previousPath); err != nil {
+			slog.Warn("Failed to remove renamed post", "error", err, "path", previousPath)
+		}
+	}
+
+	if cp.revisionsDir != "" {
+		if err := cp.keepRevision(markdown, revision); err != nil {
+			slog.Warn("Failed to keep revision", "error", err, "revision", revision)
 		}
 	}
+
+	if cp.postPathFile != "" {
+		//nolint:gosec // Working files need to be readable
+		if err := os.WriteFile(cp.postPathFile, []byte(outputPath+"\n"), 0o644); err != nil {
+			return "", fmt.Errorf("failed to record post path: %w", err)
+		}
+	}
+
+	slog.Info("Copy edit complete", "output", outputPath, "title", title, "revision", revision)
+
+	return outputPath, nil
+}
+
+// keepRevision stores a copy of the post as revision-N.md in revisionsDir.
+func (cp *copyEditPhase) keepRevision(markdown string, revision int) error {
+	if err := os.MkdirAll(cp.revisionsDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create revisions directory: %w", err)
+	}
+
+	path := filepath.Join(cp.revisionsDir, fmt.Sprintf("revision-%d.md", revision))
+
+	//nolint:gosec // Blog posts need to be readable
+	if err := os.WriteFile(path, []byte(markdown), 0o644); err != nil {
+		return fmt.Errorf("failed to write revision %s: %w", path, err)
+	}
+
+	return nil
 }
@@ -1,12 +1,15 @@
 package workflow
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/alkime/memos/internal/content"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/x/exp/teatest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,7 +32,8 @@ func TestCopyEditPhase_HappyPath(t *testing.T) {
 			Changes:  []string{"Fixed grammar", "Added frontmatter"},
 		},
 	}
-	phase := NewCopyEditPhase(writer, inputPath, content.ModeMemos, outputDir)
+	mode := content.DefaultModes()[content.ModeMemos]
+	phase := NewCopyEditPhase(context.Background(), writer, inputPath, mode, nil, CopyEditFiles{OutputDir: outputDir})
 
 	_ = teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
 	checker := defaultChecker()
@@ -59,3 +63,251 @@ func TestCopyEditPhase_HappyPath(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, string(outputContent), "My Final Post")
 }
+
+func TestCopyEditPhase_ModeFilenameAndFrontmatter(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "first-draft.md")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(inputPath, []byte("Yesterday I fixed the build."), 0o644))
+
+	writer := &mockWriter{
+		copyEditResult: &content.CopyEditResult{
+			Title:    "Monday Standup",
+			Markdown: "---\ntitle: Monday Standup\n---\n\nFixed the build.",
+			Changes:  []string{"Added frontmatter"},
+		},
+	}
+	mode := content.ModeConfig{
+		Name:        "standup",
+		Frontmatter: []string{"title", "date"},
+		Filename:    "standup-{{.Slug}}.md",
+	}.WithDefaults()
+	phase := NewCopyEditPhase(context.Background(), writer, inputPath, mode, nil, CopyEditFiles{OutputDir: tmpDir})
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
+	checker := defaultChecker()
+
+	checker.checkString(t, tm, "Missing frontmatter: date")
+	assert.FileExists(t, filepath.Join(tmpDir, "standup-monday-standup.md"))
+}
+
+func TestCopyEditPhase_RevisionLoop(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "first-draft.md")
+	revisionsDir := filepath.Join(tmpDir, "revisions")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(inputPath, []byte("# Draft"), 0o644))
+
+	writer := &mockWriter{
+		copyEditResult: &content.CopyEditResult{
+			Title:    "Slow Start",
+			Markdown: "---\ntitle: Slow Start\n---\n\nA long intro.",
+			Changes:  []string{"Fixed grammar"},
+		},
+		revisionResult: &content.CopyEditResult{
+			Title:    "Fast Start",
+			Markdown: "---\ntitle: Fast Start\n---\n\nA short intro.",
+			Changes:  []string{"Tightened the intro"},
+		},
+	}
+	mode := content.ModeConfig{Name: "test", Filename: "{{.Slug}}.md"}.WithDefaults()
+	phase := NewCopyEditPhase(context.Background(), writer, inputPath, mode, nil, CopyEditFiles{
+		OutputDir:    tmpDir,
+		RevisionsDir: revisionsDir,
+	})
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 40))
+	checker := defaultChecker()
+
+	checker.checkString(t, tm, "Fixed grammar")
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	checker.checkString(t, tm, "Feedback:")
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("tighten the intro")})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	checker.checkString(t, tm, "Revision 2")
+
+	// The renamed post replaces the first one and both revisions are kept
+	assert.NoFileExists(t, filepath.Join(tmpDir, "slow-start.md"))
+	post, err := os.ReadFile(filepath.Join(tmpDir, "fast-start.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(post), "A short intro.")
+
+	first, err := os.ReadFile(filepath.Join(revisionsDir, "revision-1.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(first), "A long intro.")
+	assert.FileExists(t, filepath.Join(revisionsDir, "revision-2.md"))
+}
+
+func TestCopyEditPhase_RevisionFails(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "first-draft.md")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(inputPath, []byte("# Draft"), 0o644))
+
+	writer := &mockWriter{
+		copyEditResult: &content.CopyEditResult{
+			Title:    "Slow Start",
+			Markdown: "---\ntitle: Slow Start\n---\n\nA long intro.",
+			Changes:  []string{"Fixed grammar"},
+		},
+		revisionErr: errors.New("overloaded"),
+	}
+	mode := content.ModeConfig{Name: "test", Filename: "{{.Slug}}.md"}.WithDefaults()
+	phase := NewCopyEditPhase(context.Background(), writer, inputPath, mode, nil, CopyEditFiles{OutputDir: tmpDir})
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 40))
+	checker := defaultChecker()
+
+	checker.checkString(t, tm, "Fixed grammar")
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	checker.checkString(t, tm, "Feedback:")
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("tighten the intro")})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// The saved post can still be revised again or accepted
+	checker.checkString(t, tm, "Revision failed: overloaded")
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	checker.checkString(t, tm, "Feedback:")
+	assert.FileExists(t, filepath.Join(tmpDir, "slow-start.md"))
+}
+
+func TestCopyEditPhase_AcceptNewTag(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "first-draft.md")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(inputPath, []byte("# Draft"), 0o644))
+
+	writer := &mockWriter{
+		copyEditResult: &content.CopyEditResult{
+			Title:        "Tagged",
+			Markdown:     "---\ntitle: \"Tagged\"\ntags: [\"Go\"]\n---\n\nBody.",
+			Changes:      []string{"Added tags"},
+			ExistingTags: []string{"Go"},
+			NewTags:      []string{"Rust", "WebAssembly"},
+		},
+	}
+	mode := content.ModeConfig{Name: "test", Filename: "{{.Slug}}.md"}.WithDefaults()
+	phase := NewCopyEditPhase(context.Background(), writer, inputPath, mode, nil, CopyEditFiles{OutputDir: tmpDir})
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 40))
+	checker := defaultChecker()
+
+	checker.checkString(t, tm, "WebAssembly")
+
+	// New tags stay out of the saved post until accepted
+	outputPath := filepath.Join(tmpDir, "tagged.md")
+	post, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(post), "tags: [\"Go\"]")
+
+	// Accept the second tag only
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
+	tm.Send(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	checker.checkString(t, tm, "[x] WebAssembly")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	require.Eventually(t, func() bool {
+		post, err := os.ReadFile(outputPath)
+		return err == nil && strings.Contains(string(post), "tags: [\"Go\", \"WebAssembly\"]")
+	}, checker.timeout, checker.intervl, "Accepted tag should be saved")
+}
+
+func TestCopyEditPhase_ChooseTitle(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "first-draft.md")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(inputPath, []byte("# Draft"), 0o644))
+
+	postPathFile := filepath.Join(tmpDir, "post-path.txt")
+	writer := &mockWriter{
+		copyEditResult: &content.CopyEditResult{
+			Title:           "Slow Start",
+			TitleCandidates: []string{"Slow Start", "Warming Up", "Cold Engines"},
+			Markdown:        "---\ntitle: \"Slow Start\"\n---\n\n# Slow Start\n\nA long intro.",
+			Changes:         []string{"Fixed grammar"},
+		},
+	}
+	mode := content.ModeConfig{Name: "test", Filename: "{{.Slug}}.md"}.WithDefaults()
+	phase := NewCopyEditPhase(context.Background(), writer, inputPath, mode, nil, CopyEditFiles{
+		OutputDir:    tmpDir,
+		PostPathFile: postPathFile,
+	})
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 40))
+	checker := defaultChecker()
+
+	checker.checkString(t, tm, "Cold Engines")
+	assert.FileExists(t, filepath.Join(tmpDir, "slow-start.md"))
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	checker.checkString(t, tm, "(•) Warming Up")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// The post is renamed after the chosen title
+	outputPath := filepath.Join(tmpDir, "warming-up.md")
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(outputPath)
+		return err == nil
+	}, checker.timeout, checker.intervl, "Post should be saved under the chosen title")
+
+	post, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(post), "title: \"Warming Up\"")
+	assert.Contains(t, string(post), "# Warming Up\n\nA long intro.")
+	assert.NoFileExists(t, filepath.Join(tmpDir, "slow-start.md"))
+
+	// Later phases find the renamed post
+	recorded, err := os.ReadFile(postPathFile)
+	require.NoError(t, err)
+	assert.Equal(t, outputPath+"\n", string(recorded))
+}
+
+func TestCopyEditPhase_ApproveLink(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "first-draft.md")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(inputPath, []byte("# Draft"), 0o644))
+
+	writer := &mockWriter{
+		copyEditResult: &content.CopyEditResult{
+			Title:    "Linked",
+			Markdown: "---\ntitle: \"Linked\"\n---\n\nAs I said in the voice CLI kickoff, it starts small.",
+			Changes:  []string{"Fixed grammar"},
+			CrossLinks: []content.CrossLink{
+				{Slug: "2025-11-voice-cli-kickoff", Title: "Voice CLI Kickoff", Text: "voice CLI kickoff"},
+			},
+		},
+	}
+	mode := content.ModeConfig{Name: "test", Filename: "{{.Slug}}.md"}.WithDefaults()
+	phase := NewCopyEditPhase(context.Background(), writer, inputPath, mode, nil, CopyEditFiles{OutputDir: tmpDir})
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 40))
+	checker := defaultChecker()
+
+	checker.checkString(t, tm, "Links to earlier posts:")
+
+	// Links stay out of the saved post until approved
+	outputPath := filepath.Join(tmpDir, "linked.md")
+	post, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(post), "relref")
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	checker.checkString(t, tm, "[x]")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	require.Eventually(t, func() bool {
+		post, err := os.ReadFile(outputPath)
+		return err == nil && strings.Contains(string(post),
+			`in the [voice CLI kickoff]({{< relref "2025-11-voice-cli-kickoff.md" >}}), it`)
+	}, checker.timeout, checker.intervl, "Approved link should be saved")
+}
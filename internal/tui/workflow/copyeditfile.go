@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -25,38 +26,65 @@ const (
 )
 
 type copyEditFilePhase struct {
+	ctx      context.Context
 	spinner  labeledspinner.Model
 	filePath string
-	mode     content.Mode
+	mode     content.ModeConfig
+	tags     *content.TagIndex
 	client   Writer
 	state    copyEditFileState
 
-	// Result from Claude
+	// cancel aborts the in-flight copy edit request
+	cancel  context.CancelFunc
+	retries *retryWatcher
+
+	// Result from the model
 	result *content.CopyEditResult
+	titles titlePicker
+	links  linkPicker
+	picker tagPicker
+	// problems lists what failed content.ValidateCopyEdit
+	problems []string
 
 	// Final state
 	applied bool
 }
 
-// NewCopyEditFilePhase creates a new copy-edit file phase.
-func NewCopyEditFilePhase(writer Writer, filePath string, mode content.Mode) tea.Model {
+// NewCopyEditFilePhase creates a new copy-edit file phase. Tags missing from
+// tags, the blog's taxonomy, and suggested links to earlier posts are only
+// applied once the author accepts them.
+// Choosing another title candidate changes the frontmatter but not the path.
+// Requests are cancelled when ctx is done or the user quits.
+func NewCopyEditFilePhase(
+	ctx context.Context,
+	writer Writer,
+	filePath string,
+	mode content.ModeConfig,
+	tags *content.TagIndex,
+) tea.Model {
 	filename := filepath.Base(filePath)
 	return &copyEditFilePhase{
+		ctx: ctx,
 		spinner: labeledspinner.New(
 			spinner.Pulse,
 			fmt.Sprintf("Copy editing %s...", filename),
-			"Claude is polishing your post",
+			"The model is polishing your post",
 			"This may take a moment",
 		),
 		filePath: filePath,
 		mode:     mode,
+		tags:     tags,
 		client:   writer,
 		state:    copyEditFileProcessing,
+		titles:   newTitlePicker(),
+		links:    newLinkPicker(),
+		picker:   newTagPicker(),
 	}
 }
 
 type copyEditFileCompleteMsg struct {
-	result *content.CopyEditResult
+	result   *content.CopyEditResult
+	problems []string
 }
 
 type copyEditFileErrorMsg struct {
@@ -75,11 +103,18 @@ func (cef *copyEditFilePhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 	case copyEditFileCompleteMsg:
 		cef.state = copyEditFileReview
 		cef.result = msg.result
+		cef.titles.setTitles(msg.result.TitleCandidates)
+		cef.links.setLinks(msg.result.CrossLinks)
+		cef.picker.setTags(msg.result.NewTags)
+		cef.problems = msg.problems
 
 		return cef, nil
 
+	case retryMsg:
+		return cef, cef.retries.update(msg)
+
 	case copyEditFileErrorMsg:
-		slog.Error("Copy edit failed", "error", msg.err)
+		logRequestError("Copy edit failed", msg.err)
 		return cef, tea.Quit
 
 	case tea.KeyMsg:
@@ -100,8 +135,20 @@ func (cef *copyEditFilePhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 func (cef *copyEditFilePhase) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	km := DefaultKeyMap()
 
+	if cef.state == copyEditFileReview {
+		for _, update := range []func(tea.KeyMsg) (bool, bool){cef.titles.update, cef.links.update, cef.picker.update} {
+			if handled, _ := update(msg); handled {
+				return cef, nil
+			}
+		}
+	}
+
 	switch {
 	case key.Matches(msg, km.Quit), key.Matches(msg, km.ForceQuit):
+		if cef.cancel != nil {
+			cef.cancel()
+		}
+
 		// In review state, q/esc discards changes
 		if cef.state == copyEditFileReview {
 			cef.state = copyEditFileCompleted
@@ -125,7 +172,7 @@ func (cef *copyEditFilePhase) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 func (cef *copyEditFilePhase) View() string {
 	switch cef.state {
 	case copyEditFileProcessing:
-		return cef.spinner.View()
+		return cef.spinner.ViewWithHelp(cef.retries.helpOr(cef.spinner.Help))
 	case copyEditFileReview:
 		return cef.reviewView()
 	case copyEditFileCompleted:
@@ -143,10 +190,22 @@ func (cef *copyEditFilePhase) reviewView() string {
 	sb.WriteString("\n\n")
 
 	// Title
+	title := cef.titles.title()
+	if title == "" {
+		title = cef.result.Title
+	}
 	sb.WriteString(style.Label.Render("Title: "))
-	sb.WriteString(cef.result.Title)
+	sb.WriteString(title)
 	sb.WriteString("\n\n")
 
+	if len(cef.problems) > 0 {
+		for _, problem := range cef.problems {
+			sb.WriteString(style.Warning.Render(problem))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
 	// Changes list
 	sb.WriteString(style.Label.Render("Changes:"))
 	sb.WriteString("\n")
@@ -158,6 +217,10 @@ func (cef *copyEditFilePhase) reviewView() string {
 	}
 	sb.WriteString("\n")
 
+	sb.WriteString(cef.titles.view())
+	sb.WriteString(cef.links.view())
+	sb.WriteString(cef.picker.view())
+
 	// Action help
 	sb.WriteString(style.Help.Render("["))
 	sb.WriteString(style.Key.Render("Enter"))
@@ -189,31 +252,48 @@ func (cef *copyEditFilePhase) completedView() string {
 }
 
 func (cef *copyEditFilePhase) copyEditCmd() tea.Cmd {
-	return func() tea.Msg {
+	retries := newRetryWatcher()
+	cef.retries = retries
+
+	ctx, cancel := context.WithTimeout(retries.context(cef.ctx), copyEditTimeout)
+	cef.cancel = cancel
+
+	return tea.Batch(retries.waitCmd(), func() tea.Msg {
+		defer cancel()
+		defer retries.done()
+
 		// Read file content
 		fileContent, err := os.ReadFile(cef.filePath)
 		if err != nil {
 			return copyEditFileErrorMsg{err: fmt.Errorf("failed to read file %s: %w", cef.filePath, err)}
 		}
 
-		// Generate copy edit via Claude API
+		// Generate copy edit via the LLM provider
 		currentDate := time.Now().Format("2006-01-02")
-		result, err := cef.client.GenerateCopyEdit(string(fileContent), currentDate, cef.mode)
+		result, err := cef.client.GenerateCopyEdit(ctx, string(fileContent), currentDate, cef.mode.Name, cef.tags)
 		if err != nil {
 			return copyEditFileErrorMsg{err: fmt.Errorf("copy edit generation failed: %w", err)}
 		}
 
 		slog.Info("Copy edit complete", "title", result.Title, "changes", len(result.Changes))
 
-		return copyEditFileCompleteMsg{result: result}
-	}
+		problems := content.ValidateCopyEdit(string(fileContent), result.Markdown, cef.mode)
+		if len(problems) > 0 {
+			slog.Warn("Copy-edited post failed validation", "mode", cef.mode.Name, "problems", problems)
+		}
+
+		return copyEditFileCompleteMsg{result: result, problems: problems}
+	})
 }
 
 func (cef *copyEditFilePhase) applyChangesCmd() tea.Cmd {
+	post := withTitle(cef.result, cef.titles.title()).WithLinks(cef.links.approvedLinks())
+	markdown := withAcceptedTags(post, cef.picker.acceptedTags())
+
 	return func() tea.Msg {
 		// Write the copy-edited content back to the file
 		//nolint:gosec // Blog posts need to be readable
-		if err := os.WriteFile(cef.filePath, []byte(cef.result.Markdown), 0o644); err != nil {
+		if err := os.WriteFile(cef.filePath, []byte(markdown), 0o644); err != nil {
 			slog.Error("Failed to write file", "error", err, "path", cef.filePath)
 			return copyEditFileErrorMsg{err: fmt.Errorf("failed to write file %s: %w", cef.filePath, err)}
 		}
@@ -1,9 +1,11 @@
 package workflow
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/alkime/memos/internal/content"
 	tea "github.com/charmbracelet/bubbletea"
@@ -27,7 +29,8 @@ func TestCopyEditFilePhase_HappyPath(t *testing.T) {
 			Changes:  []string{"Improved title", "Added frontmatter"},
 		},
 	}
-	phase := NewCopyEditFilePhase(writer, filePath, content.ModeMemos)
+	mode := content.DefaultModes()[content.ModeMemos]
+	phase := NewCopyEditFilePhase(context.Background(), writer, filePath, mode, nil)
 
 	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
 	checker := defaultChecker()
@@ -66,7 +69,8 @@ func TestCopyEditFilePhase_Discard(t *testing.T) {
 			Changes:  []string{"Improved title"},
 		},
 	}
-	phase := NewCopyEditFilePhase(writer, filePath, content.ModeMemos)
+	mode := content.DefaultModes()[content.ModeMemos]
+	phase := NewCopyEditFilePhase(context.Background(), writer, filePath, mode, nil)
 
 	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
 	checker := defaultChecker()
@@ -85,3 +89,37 @@ func TestCopyEditFilePhase_Discard(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, originalContent, string(preservedContent), "Original content should be preserved when discarding")
 }
+
+func TestCopyEditFilePhase_QuitCancelsRequest(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "my-post.md")
+
+	originalContent := "# Original Title\n\nOriginal content here."
+	require.NoError(t, os.WriteFile(filePath, []byte(originalContent), 0o644)) //nolint:gosec // Test file
+
+	writer := &mockWriter{block: true, cancelled: make(chan error, 1)}
+	mode := content.DefaultModes()[content.ModeMemos]
+	phase := NewCopyEditFilePhase(context.Background(), writer, filePath, mode, nil)
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
+	checker := defaultChecker()
+
+	// Request is in flight while the spinner shows
+	checker.checkString(t, tm, "Copy editing")
+
+	// Quitting mid-request aborts it
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+
+	select {
+	case err := <-writer.cancelled:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(checker.timeout):
+		t.Fatal("copy edit request was not cancelled")
+	}
+
+	tm.WaitFinished(t, teatest.WithFinalTimeout(checker.timeout))
+
+	preservedContent, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, originalContent, string(preservedContent))
+}
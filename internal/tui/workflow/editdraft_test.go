@@ -25,9 +25,11 @@ func TestEditDraftPhase_HappyPath(t *testing.T) {
 
 	// Wait for editor to be launched (after the 250ms delay)
 	require.Eventually(t, func() bool {
-		return launcher.launched
+		_, launched := launcher.launchedWith()
+		return launched
 	}, 1*time.Second, 50*time.Millisecond, "Editor launcher should be called")
 
 	// Verify launcher was called with correct path
-	assert.Equal(t, draftPath, launcher.filePath, "Editor should be launched with correct file path")
+	filePath, _ := launcher.launchedWith()
+	assert.Equal(t, draftPath, filePath, "Editor should be launched with correct file path")
 }
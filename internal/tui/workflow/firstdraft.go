@@ -1,33 +1,137 @@
 package workflow
 
 import (
+	"context"
 	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/alkime/memos/internal/content"
 	"github.com/alkime/memos/internal/tui/components/labeledspinner"
 	"github.com/alkime/memos/internal/tui/components/phases"
+	"github.com/alkime/memos/internal/tui/style"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+type firstDraftKeyMap struct {
+	Abort       key.Binding
+	Regenerate  key.Binding
+	KeepPartial key.Binding
+}
+
+func defaultFirstDraftKeyMap() firstDraftKeyMap {
+	return firstDraftKeyMap{
+		Abort: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "abort generation"),
+		),
+		Regenerate: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "regenerate"),
+		),
+		KeepPartial: key.NewBinding(
+			key.WithKeys("k"),
+			key.WithHelp("k", "keep partial draft"),
+		),
+	}
+}
+
+// draftTextMsg carries the draft generated so far.
+type draftTextMsg struct {
+	stream *draftStream
+	text   string
+}
+
+// draftFailedMsg reports that generation ended with an error, including aborts.
+type draftFailedMsg struct {
+	stream *draftStream
+	err    error
+}
+
+// draftStream relays streamed draft text from the request goroutine into the
+// Bubble Tea loop. Only the latest text matters, so older updates are dropped.
+type draftStream struct {
+	updates chan string
+	text    string
+}
+
+func newDraftStream() *draftStream {
+	return &draftStream{
+		updates: make(chan string, 1),
+	}
+}
+
+// send replaces any undelivered text with the latest.
+func (ds *draftStream) send(text string) {
+	select {
+	case <-ds.updates:
+	default:
+	}
+
+	select {
+	case ds.updates <- text:
+	default:
+	}
+}
+
+// done closes the update stream once the request has finished.
+func (ds *draftStream) done() {
+	close(ds.updates)
+}
+
+// waitCmd waits for the next text update. It must be re-issued after each draftTextMsg.
+func (ds *draftStream) waitCmd() tea.Cmd {
+	return func() tea.Msg {
+		text, ok := <-ds.updates
+		if !ok {
+			return nil
+		}
+
+		return draftTextMsg{stream: ds, text: text}
+	}
+}
+
 type firstDraftPhase struct {
+	ctx            context.Context
 	spinner        labeledspinner.Model
 	transcriptPath string
 	outputPath     string
 	mode           content.Mode
 	client         Writer
 	existingOutput existingOutputState
+	retries        *retryWatcher
+
+	// sourceLanguagePath names the spoken language of a translated transcript
+	sourceLanguagePath string
+
+	// Streaming state
+	keys     firstDraftKeyMap
+	stream   *draftStream
+	cancel   context.CancelFunc
+	aborted  bool
+	viewport viewport.Model
 }
 
-// NewFirstDraftPhase creates a new first draft generation phase.
-func NewFirstDraftPhase(writer Writer, transcriptPath, outputPath string, mode content.Mode) tea.Model {
+// NewFirstDraftPhase creates a new first draft generation phase. The draft is
+// rendered as it streams in and generation can be aborted partway.
+// When sourceLanguagePath is set, the transcript was translated and the draft
+// opens with a comment naming the language read from that file.
+// Requests are cancelled when ctx is done.
+func NewFirstDraftPhase(
+	ctx context.Context,
+	writer Writer,
+	transcriptPath, outputPath, sourceLanguagePath string,
+	mode content.Mode,
+) tea.Model {
 	return &firstDraftPhase{
+		ctx: ctx,
 		spinner: labeledspinner.New(
 			spinner.Pulse,
 			"Generating first draft...",
-			"Claude is processing your transcript",
+			"The model is processing your transcript",
 			"This may take a moment",
 		),
 		transcriptPath: transcriptPath,
@@ -35,6 +139,11 @@ func NewFirstDraftPhase(writer Writer, transcriptPath, outputPath string, mode c
 		mode:           mode,
 		client:         writer,
 		existingOutput: newExistingOutputState(outputPath),
+
+		sourceLanguagePath: sourceLanguagePath,
+
+		keys:     defaultFirstDraftKeyMap(),
+		viewport: viewport.New(76, 10),
 	}
 }
 
@@ -45,12 +154,19 @@ func (fp *firstDraftPhase) Init() tea.Cmd {
 	}
 
 	return tea.Sequence(
+		tea.WindowSize(),
 		fp.spinner.Init(),
 		fp.generateCmd(),
 	)
 }
 
 func (fp *firstDraftPhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := teaMsg.(tea.WindowSizeMsg); ok {
+		fp.resize(msg.Width, msg.Height)
+
+		return fp, nil
+	}
+
 	// Handle existing output keybindings
 	if fp.existingOutput.found {
 		if keyMsg, ok := teaMsg.(tea.KeyMsg); ok {
@@ -67,40 +183,206 @@ func (fp *firstDraftPhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 		return fp, nil
 	}
 
+	switch msg := teaMsg.(type) {
+	case retryMsg:
+		return fp, fp.retries.update(msg)
+
+	case draftTextMsg:
+		if msg.stream != fp.stream {
+			return fp, nil
+		}
+		fp.setText(msg.text)
+
+		return fp, fp.stream.waitCmd()
+
+	case draftFailedMsg:
+		if msg.stream != fp.stream {
+			return fp, nil
+		}
+
+		if fp.aborted {
+			slog.Debug("First draft generation aborted", "error", msg.err)
+			return fp, nil
+		}
+
+		logRequestError("First draft generation failed", msg.err)
+
+		return fp, tea.Quit
+
+	case tea.KeyMsg:
+		return fp.handleKeyMsg(msg)
+	}
+
 	var cmd tea.Cmd
 	fp.spinner, cmd = fp.spinner.Update(teaMsg)
 
 	return fp, cmd
 }
 
+func (fp *firstDraftPhase) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !fp.aborted {
+		if key.Matches(msg, fp.keys.Abort) && fp.cancel != nil {
+			fp.aborted = true
+			fp.cancel()
+
+			return fp, nil
+		}
+
+		var cmd tea.Cmd
+		fp.viewport, cmd = fp.viewport.Update(msg)
+
+		return fp, cmd
+	}
+
+	switch {
+	case key.Matches(msg, fp.keys.Regenerate):
+		fp.aborted = false
+		fp.setText("")
+
+		return fp, tea.Sequence(fp.spinner.Init(), fp.generateCmd())
+
+	case key.Matches(msg, fp.keys.KeepPartial) && strings.TrimSpace(fp.stream.text) != "":
+		return fp, fp.saveCmd(fp.stream.text)
+	}
+
+	var cmd tea.Cmd
+	fp.viewport, cmd = fp.viewport.Update(msg)
+
+	return fp, cmd
+}
+
 func (fp *firstDraftPhase) View() string {
 	if fp.existingOutput.found {
 		return renderExistingOutputView(fp.existingOutput, "First draft")
 	}
 
-	return fp.spinner.View()
+	var sb strings.Builder
+
+	if fp.aborted {
+		sb.WriteString(style.Warning.Render("First draft generation aborted"))
+		sb.WriteString("\n\n")
+	} else {
+		help := fp.spinner.Help
+		if fp.stream != nil && fp.stream.text != "" {
+			help = "Streaming draft..."
+		}
+		sb.WriteString(fp.spinner.ViewWithHelp(fp.retries.helpOr(help)))
+		sb.WriteString("\n\n")
+	}
+
+	if fp.stream != nil && fp.stream.text != "" {
+		sb.WriteString(style.Viewport.Render(fp.viewport.View()))
+		sb.WriteString("\n\n")
+	}
+
+	if fp.aborted {
+		sb.WriteString(renderKeyHelp(fp.keys.Regenerate, " "))
+		if strings.TrimSpace(fp.stream.text) != "" {
+			sb.WriteString(renderKeyHelp(fp.keys.KeepPartial, " "))
+		}
+		sb.WriteString("\n")
+		sb.WriteString(renderGlobalKeyHelp())
+	} else {
+		sb.WriteString(renderKeyHelp(fp.keys.Abort, "\n"))
+	}
+
+	return sb.String()
+}
+
+func (fp *firstDraftPhase) resize(width, height int) {
+	// Leave room for the spinner header (5 lines), viewport border (2 lines)
+	// and key help (3 lines), plus the root model's phase header (2 lines)
+	fp.viewport.Width = max(width-4, 10)
+	fp.viewport.Height = max(height-12, 5)
+	fp.setText(fp.textOrEmpty())
+}
+
+func (fp *firstDraftPhase) textOrEmpty() string {
+	if fp.stream == nil {
+		return ""
+	}
+
+	return fp.stream.text
+}
+
+// setText shows the draft so far, following the newest tokens.
+func (fp *firstDraftPhase) setText(text string) {
+	if fp.stream != nil {
+		fp.stream.text = text
+	}
+
+	fp.viewport.SetContent(wrapText(text, fp.viewport.Width))
+	fp.viewport.GotoBottom()
 }
 
 func (fp *firstDraftPhase) generateCmd() tea.Cmd {
-	return func() tea.Msg {
+	retries := newRetryWatcher()
+	fp.retries = retries
+
+	stream := newDraftStream()
+	fp.stream = stream
+
+	ctx, cancel := context.WithCancel(fp.ctx)
+	fp.cancel = cancel
+
+	return tea.Batch(retries.waitCmd(), stream.waitCmd(), func() tea.Msg {
+		defer cancel()
+		defer retries.done()
+		defer stream.done()
+
+		ctx, cancel := context.WithTimeout(retries.context(ctx), firstDraftTimeout)
+		defer cancel()
+
 		content, err := os.ReadFile(fp.transcriptPath)
 		if err != nil {
 			slog.Error("Failed to read transcript file", "error", err)
-			return tea.Quit
+			return tea.Quit()
 		}
 
-		draft, err := fp.client.GenerateFirstDraft(string(content), fp.mode)
+		draft, err := fp.client.GenerateFirstDraft(ctx, string(content), fp.mode, stream.send)
 		if err != nil {
-			slog.Error("First draft generation failed", "error", err)
-			return tea.Quit
+			return draftFailedMsg{stream: stream, err: err}
+		}
+
+		return fp.saveCmd(draft)()
+	})
+}
+
+// saveCmd writes the draft, prefixed with the source language note, and
+// advances to the next phase.
+func (fp *firstDraftPhase) saveCmd(draft string) tea.Cmd {
+	return func() tea.Msg {
+		if note := fp.sourceLanguageNote(); note != "" {
+			draft = note + "\n\n" + draft
 		}
 
 		//nolint:gosec // Transcript files need to be readable
 		if err := os.WriteFile(fp.outputPath, []byte(draft), 0o644); err != nil {
 			slog.Error("Failed to write first draft", "error", err)
-			return tea.Quit
+			return tea.Quit()
 		}
 
 		return phases.NextPhaseMsg{}
 	}
 }
+
+// sourceLanguageNote returns an HTML comment recording the language a
+// translated transcript was spoken in, or "" when it was not translated.
+func (fp *firstDraftPhase) sourceLanguageNote() string {
+	if fp.sourceLanguagePath == "" {
+		return ""
+	}
+
+	language, err := os.ReadFile(fp.sourceLanguagePath)
+	if err != nil {
+		slog.Warn("Failed to read source language", "error", err)
+		return ""
+	}
+
+	name := strings.TrimSpace(string(language))
+	if name == "" || strings.EqualFold(name, "English") {
+		return ""
+	}
+
+	return "<!-- Translated from " + name + "; the original transcript is kept in the working directory. -->"
+}
@@ -1,9 +1,12 @@
 package workflow
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/alkime/memos/internal/content"
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,7 +25,7 @@ func TestFirstDraftPhase_HappyPath(t *testing.T) {
 	require.NoError(t, os.WriteFile(transcriptPath, []byte("This is my transcript content."), 0o644))
 
 	writer := &mockWriter{firstDraftResult: "# My First Draft\n\nThis is the generated content."}
-	phase := NewFirstDraftPhase(writer, transcriptPath, outputPath, content.ModeMemos)
+	phase := NewFirstDraftPhase(context.Background(), writer, transcriptPath, outputPath, "", content.ModeMemos)
 
 	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
 	checker := defaultChecker()
@@ -57,7 +60,7 @@ func TestFirstDraftPhase_ExistingOutput(t *testing.T) {
 	require.NoError(t, os.WriteFile(outputPath, []byte("existing draft content"), 0o644))
 
 	writer := &mockWriter{firstDraftResult: "new draft"}
-	phase := NewFirstDraftPhase(writer, transcriptPath, outputPath, content.ModeMemos)
+	phase := NewFirstDraftPhase(context.Background(), writer, transcriptPath, outputPath, "", content.ModeMemos)
 
 	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
 	checker := defaultChecker()
@@ -76,3 +79,91 @@ func TestFirstDraftPhase_ExistingOutput(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "existing draft content", string(existingContent))
 }
+
+func TestFirstDraftPhase_RecordsSourceLanguage(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.txt")
+	outputPath := filepath.Join(tmpDir, "first-draft.md")
+	languagePath := filepath.Join(tmpDir, "source-language.txt")
+
+	//nolint:gosec // Test files
+	require.NoError(t, os.WriteFile(transcriptPath, []byte("translated transcript"), 0o644))
+	//nolint:gosec // Test files
+	require.NoError(t, os.WriteFile(languagePath, []byte("German\n"), 0o644))
+
+	writer := &mockWriter{firstDraftResult: "# Draft"}
+	phase := NewFirstDraftPhase(
+		context.Background(), writer, transcriptPath, outputPath, languagePath, content.ModeMemos,
+	)
+
+	_ = teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
+	checker := defaultChecker()
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(outputPath)
+		return err == nil
+	}, checker.timeout, checker.intervl, "Draft file should be created")
+
+	draft, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(draft), "<!-- Translated from German;"),
+		"Draft should record the source language")
+	assert.Contains(t, string(draft), "# Draft")
+}
+
+func TestFirstDraftPhase_StreamsAndAborts(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.txt")
+	outputPath := filepath.Join(tmpDir, "first-draft.md")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(transcriptPath, []byte("transcript"), 0o644))
+
+	writer := &mockWriter{
+		partialDraft: "## Streaming heading\n\nThe first paragraph",
+		block:        true,
+		cancelled:    make(chan error, 1),
+	}
+	phase := NewFirstDraftPhase(context.Background(), writer, transcriptPath, outputPath, "", content.ModeMemos)
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
+	checker := defaultChecker()
+
+	// Partial text is rendered while the request is still running
+	checker.checkString(t, tm, "Streaming heading")
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	select {
+	case err := <-writer.cancelled:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(checker.timeout):
+		t.Fatal("Abort should cancel the request")
+	}
+	checker.checkString(t, tm, "keep partial draft")
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(outputPath)
+		return err == nil
+	}, checker.timeout, checker.intervl, "Partial draft should be saved")
+
+	draft, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "## Streaming heading\n\nThe first paragraph", string(draft))
+}
+
+func TestFirstDraftPhase_SaveFailureQuits(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.txt")
+	outputPath := filepath.Join(tmpDir, "missing", "first-draft.md")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(transcriptPath, []byte("Transcript."), 0o644))
+
+	writer := &mockWriter{firstDraftResult: "# Draft"}
+	phase := NewFirstDraftPhase(context.Background(), writer, transcriptPath, outputPath, "", content.ModeMemos)
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
+
+	tm.WaitFinished(t, teatest.WithFinalTimeout(defaultChecker().timeout))
+	assert.True(t, writer.firstDraftCalled)
+}
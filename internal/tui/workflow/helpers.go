@@ -1,12 +1,26 @@
 package workflow
 
 import (
+	"context"
+	"errors"
+	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/alkime/memos/internal/tui/style"
 	"github.com/charmbracelet/bubbles/key"
 )
 
+// Per-phase deadlines for network requests. Whisper uploads of long memos are
+// slow, so transcription gets the most headroom.
+const (
+	transcribeTimeout = 10 * time.Minute
+	translateTimeout  = 3 * time.Minute
+	firstDraftTimeout = 3 * time.Minute
+	copyEditTimeout   = 3 * time.Minute
+	socialTimeout     = 2 * time.Minute
+)
+
 func renderKeyHelp(keyBinding key.Binding, suffix ...string) string {
 	s := style.Help.Render("[") + style.Key.Render(keyBinding.Help().Key) +
 		style.Help.Render("] ") +
@@ -23,3 +37,14 @@ func renderGlobalKeyHelp() string {
 	s += renderKeyHelp(km.ForceQuit, "\n")
 	return s
 }
+
+// logRequestError logs a failed network request. Cancellation means the user
+// quit mid-request, so it is not reported as an error.
+func logRequestError(msg string, err error) {
+	if errors.Is(err, context.Canceled) {
+		slog.Debug(msg, "error", err)
+		return
+	}
+
+	slog.Error(msg, "error", err)
+}
@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"context"
 	"io"
 
 	"github.com/alkime/memos/internal/content"
@@ -8,14 +9,48 @@ import (
 )
 
 // Transcriber transcribes audio to text.
+// Implementations must abort the request when ctx is done.
 type Transcriber interface {
-	TranscribeFile(audioFile io.Reader) (string, error)
+	TranscribeFile(ctx context.Context, audioFile io.Reader) (string, error)
 }
 
 // Writer generates AI content from transcripts and drafts.
+// Implementations must abort the request when ctx is done.
 type Writer interface {
-	GenerateFirstDraft(transcript string, mode content.Mode) (string, error)
-	GenerateCopyEdit(firstDraft, currentDate string, mode content.Mode) (*content.CopyEditResult, error)
+	// GenerateFirstDraft streams the draft, calling onText with the text so far.
+	GenerateFirstDraft(
+		ctx context.Context,
+		transcript string,
+		mode content.Mode,
+		onText func(draft string),
+	) (string, error)
+	// GenerateCopyEdit copy edits a draft, choosing tags from tags where
+	// possible and proposing the rest as new tags.
+	GenerateCopyEdit(
+		ctx context.Context,
+		firstDraft, currentDate string,
+		mode content.Mode,
+		tags *content.TagIndex,
+	) (*content.CopyEditResult, error)
+	// ReviseCopyEdit applies feedback to a copy-edited post.
+	ReviseCopyEdit(
+		ctx context.Context,
+		markdown, feedback string,
+		mode content.Mode,
+		tags *content.TagIndex,
+	) (*content.CopyEditResult, error)
+	TranslateTranscript(ctx context.Context, transcript string) (*content.TranslationResult, error)
+	// GenerateSocialPosts writes a post sharing the markdown on each platform.
+	GenerateSocialPosts(
+		ctx context.Context,
+		markdown string,
+		platforms []content.SocialPlatform,
+	) ([]content.SocialPost, error)
+}
+
+// Clipboard copies text for pasting elsewhere.
+type Clipboard interface {
+	Copy(text string)
 }
 
 // EditorLauncher opens files in an external editor.
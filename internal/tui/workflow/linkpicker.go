@@ -0,0 +1,132 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/alkime/memos/internal/tui/style"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type linkPickerKeyMap struct {
+	Next   key.Binding
+	Toggle key.Binding
+}
+
+func defaultLinkPickerKeyMap() linkPickerKeyMap {
+	return linkPickerKeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next link"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "approve/reject link"),
+		),
+	}
+}
+
+// linkPicker lets the author approve or reject suggested links to earlier
+// posts one by one. Links start rejected. Decisions are remembered by post
+// and phrase, so an approved link stays approved when a revision suggests it
+// again.
+type linkPicker struct {
+	keys     linkPickerKeyMap
+	links    []content.CrossLink
+	cursor   int
+	approved map[string]bool
+}
+
+func newLinkPicker() linkPicker {
+	return linkPicker{
+		keys:     defaultLinkPickerKeyMap(),
+		approved: map[string]bool{},
+	}
+}
+
+// linkKey identifies a link for remembering decisions.
+func linkKey(link content.CrossLink) string {
+	return link.Slug + "\x00" + strings.ToLower(link.Text)
+}
+
+// setLinks replaces the suggested links, e.g. after a revision.
+func (lp *linkPicker) setLinks(links []content.CrossLink) {
+	lp.links = links
+	lp.cursor = 0
+}
+
+// update moves between or toggles links. Reports whether an approval changed.
+func (lp *linkPicker) update(msg tea.KeyMsg) (handled, changed bool) {
+	if len(lp.links) == 0 {
+		return false, false
+	}
+
+	switch {
+	case key.Matches(msg, lp.keys.Next):
+		lp.cursor = (lp.cursor + 1) % len(lp.links)
+		return true, false
+
+	case key.Matches(msg, lp.keys.Toggle):
+		k := linkKey(lp.links[lp.cursor])
+		lp.approved[k] = !lp.approved[k]
+		return true, true
+	}
+
+	return false, false
+}
+
+// approvedLinks returns the approved links in suggestion order.
+func (lp linkPicker) approvedLinks() []content.CrossLink {
+	var approved []content.CrossLink
+	for _, link := range lp.links {
+		if lp.approved[linkKey(link)] {
+			approved = append(approved, link)
+		}
+	}
+
+	return approved
+}
+
+// height is the number of lines view renders.
+func (lp linkPicker) height() int {
+	if len(lp.links) == 0 {
+		return 0
+	}
+
+	// Label, links, key help and blank line
+	return len(lp.links) + 3
+}
+
+func (lp linkPicker) view() string {
+	if len(lp.links) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(style.Label.Render("Links to earlier posts:"))
+	sb.WriteString("\n")
+	for i, link := range lp.links {
+		cursor := "  "
+		if i == lp.cursor {
+			cursor = style.Key.Render("> ")
+		}
+
+		check := "[ ] "
+		if lp.approved[linkKey(link)] {
+			check = style.Success.Render("[x] ")
+		}
+
+		sb.WriteString("  ")
+		sb.WriteString(cursor)
+		sb.WriteString(check)
+		sb.WriteString(fmt.Sprintf("%q → %s", link.Text, link.Title))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(renderKeyHelp(lp.keys.Next, " "))
+	sb.WriteString(renderKeyHelp(lp.keys.Toggle, "\n\n"))
+
+	return sb.String()
+}
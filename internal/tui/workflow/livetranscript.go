@@ -0,0 +1,191 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// SpeechSegment is a chunk of audio cut from the capture stream at a pause.
+type SpeechSegment struct {
+	// Path is a WAV file. Empty for a final segment without leftover audio.
+	Path string
+	// Final marks the untranscribed tail emitted when recording finishes.
+	Final bool
+	// Incomplete means earlier segments were lost, so the preview cannot
+	// stand in for a full transcription.
+	Incomplete bool
+}
+
+// LiveTranscript transcribes speech segments in the background while
+// recording continues. Its text previews the memo during recording and is
+// reused by the Transcribing phase, which then only has to do the tail.
+type LiveTranscript struct {
+	transcriber Transcriber
+
+	mu       sync.Mutex
+	parts    []string
+	tailPath string
+	failed   bool
+	started  bool
+	expected bool // a recording was finished this session
+	done     chan struct{}
+	start    sync.Once
+}
+
+// NewLiveTranscript creates a live transcript fed by Start.
+func NewLiveTranscript(transcriber Transcriber) *LiveTranscript {
+	return &LiveTranscript{
+		transcriber: transcriber,
+		done:        make(chan struct{}),
+	}
+}
+
+// Start transcribes segments in a background goroutine until the channel is
+// closed or ctx is done. Subsequent calls, and calls with a nil channel, are no-ops.
+func (lt *LiveTranscript) Start(ctx context.Context, segments <-chan SpeechSegment) {
+	if segments == nil {
+		return
+	}
+
+	lt.start.Do(func() {
+		lt.mu.Lock()
+		lt.started = true
+		lt.mu.Unlock()
+
+		go lt.run(ctx, segments)
+	})
+}
+
+func (lt *LiveTranscript) run(ctx context.Context, segments <-chan SpeechSegment) {
+	defer close(lt.done)
+
+	for {
+		var (
+			seg SpeechSegment
+			ok  bool
+		)
+
+		select {
+		case <-ctx.Done():
+			lt.fail()
+			return
+		case seg, ok = <-segments:
+		}
+
+		if !ok {
+			// The stream ended without a final segment, so the tail is unknown
+			lt.fail()
+			return
+		}
+
+		if seg.Incomplete {
+			lt.fail()
+		}
+
+		if seg.Final {
+			lt.mu.Lock()
+			lt.tailPath = seg.Path
+			lt.mu.Unlock()
+
+			return
+		}
+
+		if lt.Failed() {
+			// The preview cannot be reused, so later segments are not worth
+			// paying for
+			continue
+		}
+
+		text, err := lt.transcribe(ctx, seg.Path)
+		if err != nil {
+			logRequestError("Live transcription of segment failed", err)
+			lt.fail()
+
+			continue
+		}
+
+		lt.mu.Lock()
+		lt.parts = append(lt.parts, strings.TrimSpace(text))
+		lt.mu.Unlock()
+	}
+}
+
+func (lt *LiveTranscript) transcribe(ctx context.Context, path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open speech segment %s: %w", path, err)
+	}
+	defer file.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, transcribeTimeout)
+	defer cancel()
+
+	text, err := lt.transcriber.TranscribeFile(ctx, file)
+	if err != nil {
+		return "", fmt.Errorf("failed to transcribe speech segment %s: %w", path, err)
+	}
+
+	return text, nil
+}
+
+func (lt *LiveTranscript) fail() {
+	lt.mu.Lock()
+	lt.failed = true
+	lt.mu.Unlock()
+}
+
+// Text returns the transcript of the segments completed so far.
+func (lt *LiveTranscript) Text() string {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	return strings.Join(lt.parts, " ")
+}
+
+// Failed reports whether the preview has a gap and cannot be reused.
+func (lt *LiveTranscript) Failed() bool {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	return lt.failed
+}
+
+// ExpectFinal records that the recording was finished this session, so a
+// final segment is on its way.
+func (lt *LiveTranscript) ExpectFinal() {
+	lt.mu.Lock()
+	lt.expected = true
+	lt.mu.Unlock()
+}
+
+// Result waits for in-flight segments and returns the transcribed prefix and
+// the path of the untranscribed tail (empty when there is none).
+// ok is false when the preview cannot replace a full transcription: it was
+// never started, no recording was finished this session, or a segment failed.
+func (lt *LiveTranscript) Result(ctx context.Context) (string, string, bool) {
+	lt.mu.Lock()
+	expected := lt.started && lt.expected
+	lt.mu.Unlock()
+
+	if !expected {
+		return "", "", false
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", "", false
+	case <-lt.done:
+	}
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	if lt.failed {
+		return "", "", false
+	}
+
+	return strings.Join(lt.parts, " "), lt.tailPath, true
+}
@@ -0,0 +1,174 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoTranscriber "transcribes" a file by returning its contents.
+type echoTranscriber struct {
+	mu    sync.Mutex
+	files []string
+}
+
+func (e *echoTranscriber) TranscribeFile(_ context.Context, audioFile io.Reader) (string, error) {
+	data, err := io.ReadAll(audioFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.files = append(e.files, string(data))
+
+	return string(data), nil
+}
+
+func (e *echoTranscriber) transcribed() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]string(nil), e.files...)
+}
+
+func writeSegment(t *testing.T, dir, name, text string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(text), 0o600))
+
+	return path
+}
+
+func TestLiveTranscript_ReusesSegments(t *testing.T) {
+	dir := t.TempDir()
+	segments := make(chan SpeechSegment, 4)
+	live := NewLiveTranscript(&echoTranscriber{})
+	live.Start(context.Background(), segments)
+
+	segments <- SpeechSegment{Path: writeSegment(t, dir, "a.wav", "First part.")}
+	segments <- SpeechSegment{Path: writeSegment(t, dir, "b.wav", "Second part.")}
+
+	require.Eventually(t, func() bool {
+		return live.Text() == "First part. Second part."
+	}, time.Second, 10*time.Millisecond)
+
+	live.ExpectFinal()
+	segments <- SpeechSegment{Path: writeSegment(t, dir, "c.wav", "Tail."), Final: true}
+	close(segments)
+
+	prefix, tailPath, ok := live.Result(context.Background())
+
+	require.True(t, ok)
+	assert.Equal(t, "First part. Second part.", prefix)
+	assert.Equal(t, filepath.Join(dir, "c.wav"), tailPath)
+}
+
+func TestLiveTranscript_NotReusableWithoutFinish(t *testing.T) {
+	segments := make(chan SpeechSegment)
+	live := NewLiveTranscript(&echoTranscriber{})
+	live.Start(context.Background(), segments)
+
+	_, _, ok := live.Result(context.Background())
+
+	assert.False(t, ok, "no recording was finished this session")
+}
+
+func TestLiveTranscript_IncompleteStream(t *testing.T) {
+	segments := make(chan SpeechSegment, 1)
+	live := NewLiveTranscript(&echoTranscriber{})
+	live.Start(context.Background(), segments)
+	live.ExpectFinal()
+
+	segments <- SpeechSegment{Final: true, Incomplete: true}
+
+	_, _, ok := live.Result(context.Background())
+
+	assert.False(t, ok)
+	assert.True(t, live.Failed())
+}
+
+func TestLiveTranscript_StopsAfterFailure(t *testing.T) {
+	dir := t.TempDir()
+	transcriber := &echoTranscriber{}
+	segments := make(chan SpeechSegment, 4)
+	live := NewLiveTranscript(transcriber)
+	live.Start(context.Background(), segments)
+	live.ExpectFinal()
+
+	segments <- SpeechSegment{Path: filepath.Join(dir, "missing.wav")}
+	segments <- SpeechSegment{Path: writeSegment(t, dir, "b.wav", "Second part.")}
+	segments <- SpeechSegment{Path: writeSegment(t, dir, "c.wav", "Tail."), Final: true}
+	close(segments)
+
+	_, _, ok := live.Result(context.Background())
+
+	assert.False(t, ok)
+	assert.Empty(t, transcriber.transcribed(), "Segments after a failure should not be transcribed")
+}
+
+func TestTranscribePhase_TranscribesOnlyTail(t *testing.T) {
+	tmpDir := t.TempDir()
+	audioPath := writeSegment(t, tmpDir, "recording.mp3", "Whole recording.")
+	transcriptPath := filepath.Join(tmpDir, "transcript.txt")
+
+	transcriber := &echoTranscriber{}
+	segments := make(chan SpeechSegment, 2)
+	live := NewLiveTranscript(transcriber)
+	live.Start(context.Background(), segments)
+	live.ExpectFinal()
+
+	segments <- SpeechSegment{Path: writeSegment(t, tmpDir, "segment-000.wav", "Hello there.")}
+	segments <- SpeechSegment{Path: writeSegment(t, tmpDir, "segment-001.wav", "Goodbye."), Final: true}
+	close(segments)
+
+	phase := NewTranscribePhase(context.Background(), transcriber, live, audioPath, transcriptPath)
+	_ = teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
+	checker := defaultChecker()
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(transcriptPath)
+		return err == nil
+	}, checker.timeout, checker.intervl, "Transcript file should be created")
+
+	transcript, err := os.ReadFile(transcriptPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello there. Goodbye.", string(transcript))
+	assert.Equal(t, []string{"Hello there.", "Goodbye."}, transcriber.transcribed(),
+		"the whole recording is never uploaded")
+}
+
+func TestRecordingPhase_ShowsLivePreview(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	segments := make(chan SpeechSegment, 1)
+	live := NewLiveTranscript(&echoTranscriber{})
+	live.Start(context.Background(), segments)
+	segments <- SpeechSegment{Path: writeSegment(t, tmpDir, "segment-000.wav", "Captured so far.")}
+
+	controls := RecordingControls{
+		FileSize:       &mockCappedDial{current: 0, max: 10240},
+		StartStopPause: &mockKnob{state: true},
+		SampleLevels:   &mockLevels{samples: []int16{100}},
+		Segments:       segments,
+		Finish:         func() {},
+	}
+
+	phase := NewRecording(controls, live, 10240, filepath.Join(tmpDir, "recording.mp3"))
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 30))
+
+	defaultChecker().checkString(t, tm, "Captured so far.")
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+}
@@ -21,9 +21,16 @@ type RecordingControls struct {
 	FileSize       remotectl.CappedDial[int64]
 	StartStopPause remotectl.Knob
 	SampleLevels   remotectl.Levels[int16] // Audio samples for waveform visualization
+	Segments       <-chan SpeechSegment    // Completed speech segments for live preview (optional)
 	Finish         func()
 }
 
+// Live transcript pane dimensions (width matches the waveform and progress bar).
+const (
+	livePreviewWidth = 40
+	livePreviewLines = 4
+)
+
 // recordingKeyMap defines the key bindings for the recording phase.
 type recordingKeyMap struct {
 	Toggle key.Binding
@@ -51,13 +58,15 @@ type recordingPhase struct {
 	stopwatch      stopwatch.Model
 	progress       progress.Model
 	waveform       waveform.Model
+	live           *LiveTranscript
 	maxBytes       int64
 	outputPath     string
 	existingOutput existingOutputState
 }
 
 // NewRecording creates a new recording phase model.
-func NewRecording(controls RecordingControls, maxBytes int64, outputPath string) tea.Model {
+// When live is non-nil, its rolling transcript is shown under the waveform.
+func NewRecording(controls RecordingControls, live *LiveTranscript, maxBytes int64, outputPath string) tea.Model {
 	s := spinner.New()
 	s.Spinner = spinner.Points
 
@@ -77,6 +86,7 @@ func NewRecording(controls RecordingControls, maxBytes int64, outputPath string)
 		stopwatch:      stopwatch.New(),
 		progress:       p,
 		waveform:       wf,
+		live:           live,
 		maxBytes:       maxBytes,
 		outputPath:     outputPath,
 		existingOutput: newExistingOutputState(outputPath),
@@ -120,6 +130,10 @@ func (r *recordingPhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 			return r, tea.Batch(cmds...)
 
 		case key.Matches(typedMsg, r.keys.Finish):
+			if r.live != nil {
+				r.live.ExpectFinal()
+			}
+
 			if r.controls.Finish != nil {
 				r.controls.Finish()
 			}
@@ -197,6 +211,12 @@ func (r *recordingPhase) View() string {
 	sb.WriteString(r.waveform.View())
 	sb.WriteString("\n\n")
 
+	// Rolling transcript of the speech captured so far
+	if r.live != nil {
+		sb.WriteString(renderLivePreview(r.live))
+		sb.WriteString("\n\n")
+	}
+
 	// Help text
 	sb.WriteString(renderKeyHelp(r.keys.Toggle, " "))
 	sb.WriteString(renderKeyHelp(r.keys.Finish, "\n"))
@@ -210,6 +230,25 @@ func (r *recordingPhase) IsRecording() bool {
 	return r.controls.StartStopPause.Read()
 }
 
+// renderLivePreview renders the last few lines of the live transcript.
+func renderLivePreview(live *LiveTranscript) string {
+	text := live.Text()
+
+	switch {
+	case live.Failed():
+		return style.Muted.Render("Live transcript unavailable; the full recording will be transcribed.")
+	case text == "":
+		return style.Muted.Render("Live transcript appears after the first pause…")
+	}
+
+	lines := strings.Split(wrapText(text, livePreviewWidth), "\n")
+	if len(lines) > livePreviewLines {
+		lines = lines[len(lines)-livePreviewLines:]
+	}
+
+	return style.Viewport.Render(strings.Join(lines, "\n"))
+}
+
 // formatBytes formats bytes as a human-readable string.
 func formatBytes(current, maxBytes int64) string {
 	currentMB := float64(current) / (1024 * 1024)
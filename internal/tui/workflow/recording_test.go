@@ -25,7 +25,7 @@ func TestRecordingPhase_HappyPath(t *testing.T) {
 		},
 	}
 
-	phase := NewRecording(controls, 10240, outputPath)
+	phase := NewRecording(controls, nil, 10240, outputPath)
 	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
 	checker := defaultChecker()
 
@@ -64,7 +64,7 @@ func TestRecordingPhase_ExistingOutput(t *testing.T) {
 		Finish:         func() {},
 	}
 
-	phase := NewRecording(controls, 10240, outputPath)
+	phase := NewRecording(controls, nil, 10240, outputPath)
 	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
 	checker := defaultChecker()
 
@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/alkime/memos/internal/content"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// retryMsg reports that an in-flight request is backing off before a retry.
+type retryMsg struct {
+	watcher *retryWatcher
+	event   content.RetryEvent
+}
+
+// retryWatcher relays retry notifications from a request goroutine into the
+// Bubble Tea loop so spinner phases can show "retrying (2/5) in 4s…".
+type retryWatcher struct {
+	events chan content.RetryEvent
+	// pending is the latest retry, counted down until retryAt
+	pending *content.RetryEvent
+	retryAt time.Time
+}
+
+func newRetryWatcher() *retryWatcher {
+	return &retryWatcher{
+		events: make(chan content.RetryEvent, 1),
+	}
+}
+
+// context attaches the watcher to ctx so content requests report their retries.
+func (rw *retryWatcher) context(ctx context.Context) context.Context {
+	return content.WithRetryNotifier(ctx, func(event content.RetryEvent) {
+		// Never block the request on a slow UI; a newer event replaces one
+		// that was not picked up yet
+		select {
+		case <-rw.events:
+		default:
+		}
+		select {
+		case rw.events <- event:
+		default:
+		}
+	})
+}
+
+// done closes the event stream once the request has finished.
+func (rw *retryWatcher) done() {
+	close(rw.events)
+}
+
+// waitCmd waits for the next retry event. It must be re-issued after each retryMsg.
+func (rw *retryWatcher) waitCmd() tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-rw.events
+		if !ok {
+			return nil
+		}
+
+		return retryMsg{watcher: rw, event: event}
+	}
+}
+
+// update records the status of a retryMsg addressed to this watcher.
+// Returns the command to keep listening, or nil for a stale message.
+func (rw *retryWatcher) update(msg retryMsg) tea.Cmd {
+	if msg.watcher != rw {
+		return nil
+	}
+
+	rw.pending = &msg.event
+	rw.retryAt = time.Now().Add(msg.event.Delay)
+
+	return rw.waitCmd()
+}
+
+// helpOr returns the retry countdown while backing off, otherwise help.
+func (rw *retryWatcher) helpOr(help string) string {
+	if rw == nil || rw.pending == nil {
+		return help
+	}
+
+	remaining := time.Until(rw.retryAt)
+	if remaining <= 0 {
+		return help
+	}
+
+	// Count down whole seconds, never showing "in 0s"
+	event := *rw.pending
+	event.Delay = (remaining + time.Second - 1).Truncate(time.Second)
+
+	return event.String()
+}
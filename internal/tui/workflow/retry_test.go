@@ -0,0 +1,51 @@
+package workflow
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryWatcher_KeepsLatestEvent(t *testing.T) {
+	rw := newRetryWatcher()
+	policy := content.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	// Nobody listens while the request retries twice
+	attempts := 0
+	err := policy.Do(rw.context(context.Background()), func(context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return &openai.Error{StatusCode: http.StatusTooManyRequests}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	msg, ok := rw.waitCmd()().(retryMsg)
+	require.True(t, ok)
+	assert.Equal(t, 3, msg.event.Attempt)
+}
+
+func TestRetryWatcher_CountsDown(t *testing.T) {
+	rw := newRetryWatcher()
+
+	rw.update(retryMsg{watcher: rw, event: content.RetryEvent{Attempt: 2, MaxAttempts: 5, Delay: time.Minute}})
+
+	assert.Equal(t, "retrying (2/5) in 1m0s…", rw.helpOr("help"))
+}
+
+func TestRetryWatcher_ClearsOnceBackoffElapsed(t *testing.T) {
+	rw := newRetryWatcher()
+
+	rw.update(retryMsg{watcher: rw, event: content.RetryEvent{Attempt: 2, MaxAttempts: 5, Delay: time.Millisecond}})
+
+	assert.Eventually(t, func() bool {
+		return rw.helpOr("help") == "help"
+	}, time.Second, 10*time.Millisecond)
+}
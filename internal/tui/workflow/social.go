@@ -0,0 +1,281 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/alkime/memos/internal/tui/components/labeledspinner"
+	"github.com/alkime/memos/internal/tui/components/phases"
+	"github.com/alkime/memos/internal/tui/style"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// OSC52Clipboard copies through the terminal's OSC 52 escape sequence, which
+// also works over SSH.
+type OSC52Clipboard struct{}
+
+// Copy sets the terminal's clipboard to text.
+func (OSC52Clipboard) Copy(text string) {
+	termenv.Copy(text)
+}
+
+type socialKeyMap struct {
+	Next key.Binding
+	Copy key.Binding
+	Done key.Binding
+}
+
+func defaultSocialKeyMap() socialKeyMap {
+	return socialKeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next post"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "copy to clipboard"),
+		),
+		Done: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "done"),
+		),
+	}
+}
+
+type socialPhase struct {
+	ctx          context.Context
+	spinner      labeledspinner.Model
+	postPathFile string
+	platforms    []content.SocialPlatform
+	clipboard    Clipboard
+	client       Writer
+	retries      *retryWatcher
+	keys         socialKeyMap
+
+	// ready is set once the posts are generated
+	ready       bool
+	posts       []content.SocialPost
+	sidecarPath string
+	selected    int
+	// status confirms the last copy
+	status string
+
+	viewport viewport.Model
+	height   int
+}
+
+// NewSocialPhase creates a phase that writes posts sharing the copy-edited
+// post, whose path the copy edit phase wrote to postPathFile, on each
+// platform. The posts are saved next to the post as a sidecar file, see
+// content.SocialSidecarPath, and can be copied to the clipboard one by one.
+// Requests are cancelled when ctx is done.
+func NewSocialPhase(
+	ctx context.Context,
+	writer Writer,
+	postPathFile string,
+	platforms []content.SocialPlatform,
+	clipboard Clipboard,
+) tea.Model {
+	return &socialPhase{
+		ctx: ctx,
+		spinner: labeledspinner.New(
+			spinner.Dot,
+			"Writing social posts...",
+			"The model is writing a post for each of your social accounts",
+			"They are saved next to your post",
+		),
+		postPathFile: postPathFile,
+		platforms:    platforms,
+		clipboard:    clipboard,
+		client:       writer,
+		keys:         defaultSocialKeyMap(),
+		viewport:     viewport.New(76, 10),
+		height:       24,
+	}
+}
+
+type socialCompleteMsg struct {
+	posts       []content.SocialPost
+	sidecarPath string
+}
+
+func (sp *socialPhase) Init() tea.Cmd {
+	return tea.Sequence(
+		tea.WindowSize(),
+		sp.spinner.Init(),
+		sp.generateCmd(),
+	)
+}
+
+func (sp *socialPhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := teaMsg.(type) {
+	case tea.WindowSizeMsg:
+		sp.viewport.Width = max(msg.Width-4, 10)
+		sp.height = msg.Height
+		sp.showSelected()
+
+		return sp, nil
+
+	case socialCompleteMsg:
+		sp.ready = true
+		sp.posts = msg.posts
+		sp.sidecarPath = msg.sidecarPath
+		sp.showSelected()
+
+		return sp, nil
+
+	case retryMsg:
+		return sp, sp.retries.update(msg)
+
+	case tea.KeyMsg:
+		if !sp.ready {
+			return sp, nil
+		}
+
+		switch {
+		case key.Matches(msg, sp.keys.Next) && len(sp.posts) > 0:
+			sp.selected = (sp.selected + 1) % len(sp.posts)
+			sp.status = ""
+			sp.showSelected()
+
+			return sp, nil
+
+		case key.Matches(msg, sp.keys.Copy) && len(sp.posts) > 0:
+			post := sp.posts[sp.selected]
+			sp.clipboard.Copy(post.Text)
+			sp.status = fmt.Sprintf("Copied the %s post to the clipboard", post.Platform.Label)
+
+			return sp, nil
+
+		case key.Matches(msg, sp.keys.Done):
+			return sp, phases.NextPhaseCmd
+		}
+
+		var cmd tea.Cmd
+		sp.viewport, cmd = sp.viewport.Update(msg)
+
+		return sp, cmd
+	}
+
+	if !sp.ready {
+		var cmd tea.Cmd
+		sp.spinner, cmd = sp.spinner.Update(teaMsg)
+
+		return sp, cmd
+	}
+
+	return sp, nil
+}
+
+func (sp *socialPhase) View() string {
+	if !sp.ready {
+		return sp.spinner.ViewWithHelp(sp.retries.helpOr(sp.spinner.Help))
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(style.Title.Render("=== Social Posts ==="))
+	sb.WriteString("\n\n")
+	sb.WriteString(style.Label.Render("Saved: "))
+	sb.WriteString(style.Muted.Render(sp.sidecarPath))
+	sb.WriteString("\n\n")
+
+	for i, post := range sp.posts {
+		cursor := "  "
+		if i == sp.selected {
+			cursor = style.Key.Render("> ")
+		}
+
+		length := fmt.Sprintf("%d/%d", post.Length(), post.Platform.MaxLength)
+		if post.TooLong() {
+			length = style.Warning.Render(length + " too long")
+		} else {
+			length = style.Muted.Render(length)
+		}
+
+		sb.WriteString(cursor)
+		sb.WriteString(style.Label.Render(post.Platform.Label))
+		sb.WriteString(" ")
+		sb.WriteString(length)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(style.Viewport.Render(sp.viewport.View()))
+	sb.WriteString("\n")
+	if sp.status != "" {
+		sb.WriteString(style.Success.Render(sp.status))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(renderKeyHelp(sp.keys.Next, " "))
+	sb.WriteString(renderKeyHelp(sp.keys.Copy, " "))
+	sb.WriteString(renderKeyHelp(sp.keys.Done, "\n"))
+	sb.WriteString(renderGlobalKeyHelp())
+
+	return sb.String()
+}
+
+// showSelected shows the selected post in the viewport, fitted below the
+// list of posts.
+func (sp *socialPhase) showSelected() {
+	// Root phase header (2), title and saved (4), list padding (1), viewport
+	// border (2), status (1) and key help (2)
+	sp.viewport.Height = max(sp.height-12-len(sp.posts), 5)
+
+	if len(sp.posts) > 0 {
+		sp.viewport.SetContent(wrapText(sp.posts[sp.selected].Text, sp.viewport.Width))
+		sp.viewport.GotoTop()
+	}
+}
+
+func (sp *socialPhase) generateCmd() tea.Cmd {
+	retries := newRetryWatcher()
+	sp.retries = retries
+
+	return tea.Batch(retries.waitCmd(), func() tea.Msg {
+		defer retries.done()
+
+		ctx, cancel := context.WithTimeout(retries.context(sp.ctx), socialTimeout)
+		defer cancel()
+
+		postPath, err := os.ReadFile(sp.postPathFile)
+		if err != nil {
+			slog.Error("Failed to read post path", "error", err)
+			return tea.Quit()
+		}
+
+		path := strings.TrimSpace(string(postPath))
+		markdown, err := os.ReadFile(path)
+		if err != nil {
+			slog.Error("Failed to read copy-edited post", "error", err, "path", path)
+			return tea.Quit()
+		}
+
+		posts, err := sp.client.GenerateSocialPosts(ctx, string(markdown), sp.platforms)
+		if err != nil {
+			logRequestError("Social post generation failed", err)
+			return tea.Quit()
+		}
+
+		sidecarPath := content.SocialSidecarPath(path)
+
+		//nolint:gosec // Social posts need to be readable
+		if err := os.WriteFile(sidecarPath, []byte(content.FormatSocialPosts(posts)), 0o644); err != nil {
+			slog.Error("Failed to write social posts", "error", err)
+			return tea.Quit()
+		}
+
+		slog.Info("Social posts written", "output", sidecarPath, "posts", len(posts))
+
+		return socialCompleteMsg{posts: posts, sidecarPath: sidecarPath}
+	})
+}
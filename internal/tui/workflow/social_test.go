@@ -0,0 +1,59 @@
+package workflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alkime/memos/internal/content"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClipboard records what was copied.
+type fakeClipboard struct {
+	copied []string
+}
+
+func (c *fakeClipboard) Copy(text string) {
+	c.copied = append(c.copied, text)
+}
+
+func TestSocialPhase_CopyPosts(t *testing.T) {
+	tmpDir := t.TempDir()
+	postPath := filepath.Join(tmpDir, "2025-11-voice-cli.md")
+	postPathFile := filepath.Join(tmpDir, "post-path.txt")
+
+	//nolint:gosec // Test files
+	require.NoError(t, os.WriteFile(postPath, []byte("---\ntitle: \"Voice CLI\"\n---\n\nI talk, it writes."), 0o644))
+	//nolint:gosec // Test files
+	require.NoError(t, os.WriteFile(postPathFile, []byte(postPath+"\n"), 0o644))
+
+	platforms := content.DefaultSocialPlatforms()
+	writer := &mockWriter{
+		socialResult: []content.SocialPost{
+			{Platform: platforms[0], Text: "I built a CLI that turns voice memos into posts."},
+			{Platform: platforms[1], Text: "Talking my way to a blog post."},
+		},
+	}
+	clipboard := &fakeClipboard{}
+	phase := NewSocialPhase(context.Background(), writer, postPathFile, platforms, clipboard)
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 30))
+	checker := defaultChecker()
+
+	checker.checkString(t, tm, "48/3000")
+
+	// The posts are saved next to the post
+	sidecar, err := os.ReadFile(filepath.Join(tmpDir, "2025-11-voice-cli.social.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(sidecar), "## Threads\n\nTalking my way to a blog post.")
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	checker.checkString(t, tm, "Copied the Threads post")
+	assert.Equal(t, []string{"Talking my way to a blog post."}, clipboard.copied)
+}
@@ -0,0 +1,124 @@
+package workflow
+
+import (
+	"strings"
+
+	"github.com/alkime/memos/internal/tui/style"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type tagPickerKeyMap struct {
+	Next   key.Binding
+	Toggle key.Binding
+}
+
+func defaultTagPickerKeyMap() tagPickerKeyMap {
+	return tagPickerKeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next tag"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "accept/reject tag"),
+		),
+	}
+}
+
+// tagPicker lets the author accept or reject proposed new tags one by one.
+// New tags start rejected. Decisions are remembered by tag, so a tag accepted
+// once stays accepted when a revision proposes it again.
+type tagPicker struct {
+	keys     tagPickerKeyMap
+	tags     []string
+	cursor   int
+	accepted map[string]bool
+}
+
+func newTagPicker() tagPicker {
+	return tagPicker{
+		keys:     defaultTagPickerKeyMap(),
+		accepted: map[string]bool{},
+	}
+}
+
+// setTags replaces the proposed tags, e.g. after a revision.
+func (tp *tagPicker) setTags(tags []string) {
+	tp.tags = tags
+	tp.cursor = 0
+}
+
+// update moves between or toggles tags. Reports whether an acceptance changed.
+func (tp *tagPicker) update(msg tea.KeyMsg) (handled, changed bool) {
+	if len(tp.tags) == 0 {
+		return false, false
+	}
+
+	switch {
+	case key.Matches(msg, tp.keys.Next):
+		tp.cursor = (tp.cursor + 1) % len(tp.tags)
+		return true, false
+
+	case key.Matches(msg, tp.keys.Toggle):
+		tag := strings.ToLower(tp.tags[tp.cursor])
+		tp.accepted[tag] = !tp.accepted[tag]
+		return true, true
+	}
+
+	return false, false
+}
+
+// acceptedTags returns the accepted tags in proposal order.
+func (tp tagPicker) acceptedTags() []string {
+	var accepted []string
+	for _, tag := range tp.tags {
+		if tp.accepted[strings.ToLower(tag)] {
+			accepted = append(accepted, tag)
+		}
+	}
+
+	return accepted
+}
+
+// height is the number of lines view renders.
+func (tp tagPicker) height() int {
+	if len(tp.tags) == 0 {
+		return 0
+	}
+
+	// Label, tags, key help and blank line
+	return len(tp.tags) + 3
+}
+
+func (tp tagPicker) view() string {
+	if len(tp.tags) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(style.Label.Render("New tags (not used on the blog yet):"))
+	sb.WriteString("\n")
+	for i, tag := range tp.tags {
+		cursor := "  "
+		if i == tp.cursor {
+			cursor = style.Key.Render("> ")
+		}
+
+		check := "[ ] "
+		if tp.accepted[strings.ToLower(tag)] {
+			check = style.Success.Render("[x] ")
+		}
+
+		sb.WriteString("  ")
+		sb.WriteString(cursor)
+		sb.WriteString(check)
+		sb.WriteString(tag)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(renderKeyHelp(tp.keys.Next, " "))
+	sb.WriteString(renderKeyHelp(tp.keys.Toggle, "\n\n"))
+
+	return sb.String()
+}
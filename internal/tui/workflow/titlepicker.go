@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"strings"
+
+	"github.com/alkime/memos/internal/tui/style"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type titlePickerKeyMap struct {
+	Next key.Binding
+}
+
+func defaultTitlePickerKeyMap() titlePickerKeyMap {
+	return titlePickerKeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "next title"),
+		),
+	}
+}
+
+// titlePicker lets the author choose among the generated title candidates.
+// The copy editor's own choice comes first and starts selected.
+type titlePicker struct {
+	keys     titlePickerKeyMap
+	titles   []string
+	selected int
+}
+
+func newTitlePicker() titlePicker {
+	return titlePicker{keys: defaultTitlePickerKeyMap()}
+}
+
+// setTitles replaces the candidates, e.g. after a revision, selecting the
+// first.
+func (tp *titlePicker) setTitles(titles []string) {
+	tp.titles = titles
+	tp.selected = 0
+}
+
+// update selects the next title. Reports whether the selection changed.
+func (tp *titlePicker) update(msg tea.KeyMsg) (handled, changed bool) {
+	if len(tp.titles) < 2 || !key.Matches(msg, tp.keys.Next) {
+		return false, false
+	}
+
+	tp.selected = (tp.selected + 1) % len(tp.titles)
+
+	return true, true
+}
+
+// title returns the selected title, or "" without candidates.
+func (tp titlePicker) title() string {
+	if len(tp.titles) == 0 {
+		return ""
+	}
+
+	return tp.titles[tp.selected]
+}
+
+// height is the number of lines view renders.
+func (tp titlePicker) height() int {
+	if len(tp.titles) < 2 {
+		return 0
+	}
+
+	// Label, titles, key help and blank line
+	return len(tp.titles) + 3
+}
+
+func (tp titlePicker) view() string {
+	if len(tp.titles) < 2 {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(style.Label.Render("Title candidates:"))
+	sb.WriteString("\n")
+	for i, title := range tp.titles {
+		mark := "( ) "
+		if i == tp.selected {
+			mark = style.Success.Render("(•) ")
+		}
+
+		sb.WriteString("  ")
+		sb.WriteString(mark)
+		sb.WriteString(title)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(renderKeyHelp(tp.keys.Next, "\n\n"))
+
+	return sb.String()
+}
@@ -1,8 +1,11 @@
 package workflow
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/alkime/memos/internal/tui/components/labeledspinner"
 	"github.com/alkime/memos/internal/tui/components/phases"
@@ -12,15 +15,27 @@ import (
 )
 
 type transcribePhase struct {
+	ctx                     context.Context
 	spinner                 labeledspinner.Model
 	audioInputPath          string
 	transcriptionOutputPath string
 	client                  Transcriber
 	existingOutput          existingOutputState
+	retries                 *retryWatcher
+	live                    *LiveTranscript
 }
 
-func NewTranscribePhase(transcriber Transcriber, audioInputPath, transcriptionOutputPath string) tea.Model {
+// NewTranscribePhase creates a new transcription phase.
+// Requests are cancelled when ctx is done. When live is non-nil and covers
+// the recording, only the untranscribed tail is sent to Whisper.
+func NewTranscribePhase(
+	ctx context.Context,
+	transcriber Transcriber,
+	live *LiveTranscript,
+	audioInputPath, transcriptionOutputPath string,
+) tea.Model {
 	return &transcribePhase{
+		ctx: ctx,
 		spinner: labeledspinner.New(
 			spinner.Dot,
 			"Transcribing audio...",
@@ -31,6 +46,7 @@ func NewTranscribePhase(transcriber Transcriber, audioInputPath, transcriptionOu
 		transcriptionOutputPath: transcriptionOutputPath,
 		client:                  transcriber,
 		existingOutput:          newExistingOutputState(transcriptionOutputPath),
+		live:                    live,
 	}
 }
 
@@ -63,6 +79,10 @@ func (tp *transcribePhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 		return tp, nil
 	}
 
+	if msg, ok := teaMsg.(retryMsg); ok {
+		return tp, tp.retries.update(msg)
+	}
+
 	var cmd tea.Cmd
 	tp.spinner, cmd = tp.spinner.Update(teaMsg)
 
@@ -74,30 +94,68 @@ func (tp *transcribePhase) View() string {
 		return renderExistingOutputView(tp.existingOutput, "Transcript")
 	}
 
-	return tp.spinner.View()
+	return tp.spinner.ViewWithHelp(tp.retries.helpOr(tp.spinner.Help))
 }
 
 func (tp *transcribePhase) transcribeCmd() tea.Cmd {
-	return func() tea.Msg {
-		file, err := os.Open(tp.audioInputPath)
-		if err != nil {
-			slog.Error("Failed to open audio file for transcription", "error", err)
-			return tea.Quit
-		}
-		defer file.Close()
+	retries := newRetryWatcher()
+	tp.retries = retries
+
+	return tea.Batch(retries.waitCmd(), func() tea.Msg {
+		defer retries.done()
 
-		text, err := tp.client.TranscribeFile(file)
+		ctx, cancel := context.WithTimeout(retries.context(tp.ctx), transcribeTimeout)
+		defer cancel()
+
+		text, err := tp.transcribe(ctx)
 		if err != nil {
-			slog.Error("Transcription failed", "error", err)
-			return tea.Quit
+			logRequestError("Transcription failed", err)
+			return tea.Quit()
 		}
 
 		//nolint:gosec // Transcript files need to be readable
 		if err := os.WriteFile(tp.transcriptionOutputPath, []byte(text), 0o644); err != nil {
 			slog.Error("Failed to write transcription output", "error", err)
-			return tea.Quit
+			return tea.Quit()
 		}
 
 		return phases.NextPhaseMsg{}
+	})
+}
+
+// transcribe reuses the live transcript when it covers the recording,
+// falling back to transcribing the whole audio file.
+func (tp *transcribePhase) transcribe(ctx context.Context) (string, error) {
+	if tp.live != nil {
+		if prefix, tailPath, ok := tp.live.Result(ctx); ok {
+			slog.Info("Reusing live transcript", "tail", tailPath != "")
+			if tailPath == "" {
+				return prefix, nil
+			}
+
+			tail, err := tp.transcribeFile(ctx, tailPath)
+			if err != nil {
+				return "", err
+			}
+
+			return strings.TrimSpace(prefix + " " + tail), nil
+		}
+	}
+
+	return tp.transcribeFile(ctx, tp.audioInputPath)
+}
+
+func (tp *transcribePhase) transcribeFile(ctx context.Context, path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open audio file %s for transcription: %w", path, err)
 	}
+	defer file.Close()
+
+	text, err := tp.client.TranscribeFile(ctx, file)
+	if err != nil {
+		return "", fmt.Errorf("failed to transcribe %s: %w", path, err)
+	}
+
+	return text, nil
 }
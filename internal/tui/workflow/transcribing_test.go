@@ -1,10 +1,14 @@
 package workflow
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/alkime/memos/internal/content"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/x/exp/teatest"
 	"github.com/stretchr/testify/assert"
@@ -21,7 +25,7 @@ func TestTranscribePhase_HappyPath(t *testing.T) {
 	require.NoError(t, os.WriteFile(audioPath, []byte("fake audio data"), 0o644))
 
 	transcriber := &mockTranscriber{result: "Hello world, this is my transcript."}
-	phase := NewTranscribePhase(transcriber, audioPath, transcriptPath)
+	phase := NewTranscribePhase(context.Background(), transcriber, nil, audioPath, transcriptPath)
 
 	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
 	checker := defaultChecker()
@@ -57,7 +61,7 @@ func TestTranscribePhase_ExistingOutput(t *testing.T) {
 	require.NoError(t, os.WriteFile(transcriptPath, []byte("existing transcript"), 0o644))
 
 	transcriber := &mockTranscriber{result: "new transcript"}
-	phase := NewTranscribePhase(transcriber, audioPath, transcriptPath)
+	phase := NewTranscribePhase(context.Background(), transcriber, nil, audioPath, transcriptPath)
 
 	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
 	checker := defaultChecker()
@@ -76,3 +80,44 @@ func TestTranscribePhase_ExistingOutput(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "existing transcript", string(content))
 }
+
+func TestTranscribePhase_ShowsRetryStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	audioPath := filepath.Join(tmpDir, "recording.mp3")
+	transcriptPath := filepath.Join(tmpDir, "transcript.txt")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(audioPath, []byte("fake audio data"), 0o644))
+
+	transcriber := &mockTranscriber{
+		result: "Retried transcript.",
+		retry:  &content.RetryPolicy{MaxAttempts: 5, BaseDelay: 2 * time.Second, MaxDelay: 2 * time.Second},
+	}
+	phase := NewTranscribePhase(context.Background(), transcriber, nil, audioPath, transcriptPath)
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
+	checker := defaultChecker()
+
+	// The rate-limited first attempt surfaces as a retry countdown
+	checker.checkString(t, tm, "retrying (2/5)")
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(transcriptPath)
+		return err == nil
+	}, checker.timeout, checker.intervl, "Transcript file should be created after retry")
+}
+
+func TestTranscribePhase_FailureQuits(t *testing.T) {
+	tmpDir := t.TempDir()
+	audioPath := filepath.Join(tmpDir, "recording.mp3")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(audioPath, []byte("fake audio data"), 0o644))
+
+	transcriber := &mockTranscriber{err: errors.New("network is unreachable")}
+	phase := NewTranscribePhase(context.Background(), transcriber, nil, audioPath, filepath.Join(tmpDir, "transcript.txt"))
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
+
+	tm.WaitFinished(t, teatest.WithFinalTimeout(defaultChecker().timeout))
+}
@@ -0,0 +1,136 @@
+package workflow
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/alkime/memos/internal/tui/components/labeledspinner"
+	"github.com/alkime/memos/internal/tui/components/phases"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type translatePhase struct {
+	ctx                context.Context
+	spinner            labeledspinner.Model
+	originalPath       string
+	outputPath         string
+	sourceLanguagePath string
+	client             Writer
+	existingOutput     existingOutputState
+	retries            *retryWatcher
+}
+
+// NewTranslatePhase creates a phase that translates the original-language
+// transcript at originalPath into English at outputPath. The spoken language
+// is written to sourceLanguagePath for the first draft to record.
+// Requests are cancelled when ctx is done.
+func NewTranslatePhase(
+	ctx context.Context,
+	writer Writer,
+	originalPath, outputPath, sourceLanguagePath string,
+) tea.Model {
+	return &translatePhase{
+		ctx: ctx,
+		spinner: labeledspinner.New(
+			spinner.Dot,
+			"Translating transcript...",
+			"The model is translating your transcript into English",
+			"The original transcript is kept alongside",
+		),
+		originalPath:       originalPath,
+		outputPath:         outputPath,
+		sourceLanguagePath: sourceLanguagePath,
+		client:             writer,
+		existingOutput:     newExistingOutputState(outputPath),
+	}
+}
+
+func (tp *translatePhase) Init() tea.Cmd {
+	// Skip translation if output already exists
+	if tp.existingOutput.found {
+		return nil
+	}
+
+	return tea.Sequence(
+		tp.spinner.Init(),
+		tp.translateCmd(),
+	)
+}
+
+func (tp *translatePhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
+	// Handle existing output keybindings
+	if tp.existingOutput.found {
+		if keyMsg, ok := teaMsg.(tea.KeyMsg); ok {
+			switch {
+			case key.Matches(keyMsg, tp.existingOutput.keys.UseExisting):
+				return tp, phases.NextPhaseCmd
+			case key.Matches(keyMsg, tp.existingOutput.keys.Redo):
+				tp.existingOutput.found = false
+
+				return tp, tea.Sequence(tp.spinner.Init(), tp.translateCmd())
+			}
+		}
+
+		return tp, nil
+	}
+
+	if msg, ok := teaMsg.(retryMsg); ok {
+		return tp, tp.retries.update(msg)
+	}
+
+	var cmd tea.Cmd
+	tp.spinner, cmd = tp.spinner.Update(teaMsg)
+
+	return tp, cmd
+}
+
+func (tp *translatePhase) View() string {
+	if tp.existingOutput.found {
+		return renderExistingOutputView(tp.existingOutput, "English transcript")
+	}
+
+	return tp.spinner.ViewWithHelp(tp.retries.helpOr(tp.spinner.Help))
+}
+
+func (tp *translatePhase) translateCmd() tea.Cmd {
+	retries := newRetryWatcher()
+	tp.retries = retries
+
+	return tea.Batch(retries.waitCmd(), func() tea.Msg {
+		defer retries.done()
+
+		ctx, cancel := context.WithTimeout(retries.context(tp.ctx), translateTimeout)
+		defer cancel()
+
+		original, err := os.ReadFile(tp.originalPath)
+		if err != nil {
+			slog.Error("Failed to read original transcript", "error", err)
+			return tea.Quit()
+		}
+
+		result, err := tp.client.TranslateTranscript(ctx, string(original))
+		if err != nil {
+			logRequestError("Translation failed", err)
+			return tea.Quit()
+		}
+
+		slog.Info("Translated transcript", "sourceLanguage", result.SourceLanguage)
+
+		//nolint:gosec // Transcript files need to be readable
+		if err := os.WriteFile(tp.outputPath, []byte(result.Text), 0o644); err != nil {
+			slog.Error("Failed to write translated transcript", "error", err)
+			return tea.Quit()
+		}
+
+		//nolint:gosec // Transcript files need to be readable
+		if err := os.WriteFile(tp.sourceLanguagePath, []byte(result.SourceLanguage+"\n"), 0o644); err != nil {
+			slog.Error("Failed to write source language", "error", err)
+			return tea.Quit()
+		}
+
+		return phases.NextPhaseMsg{}
+	})
+}
@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alkime/memos/internal/content"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslatePhase_HappyPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalPath := filepath.Join(tmpDir, "transcript.original.txt")
+	outputPath := filepath.Join(tmpDir, "transcript.txt")
+	languagePath := filepath.Join(tmpDir, "source-language.txt")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(originalPath, []byte("Hola, esto es una prueba."), 0o644))
+
+	writer := &mockWriter{
+		translationResult: &content.TranslationResult{
+			SourceLanguage: "Spanish",
+			Text:           "Hello, this is a test.",
+		},
+	}
+	phase := NewTranslatePhase(context.Background(), writer, originalPath, outputPath, languagePath)
+
+	_ = teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
+	checker := defaultChecker()
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(languagePath)
+		return err == nil
+	}, checker.timeout, checker.intervl, "Source language file should be created")
+
+	assert.True(t, writer.translateCalled, "Writer.TranslateTranscript should be called")
+
+	translated, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, this is a test.", string(translated))
+
+	original, err := os.ReadFile(originalPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hola, esto es una prueba.", string(original), "Original transcript should be kept")
+
+	language, err := os.ReadFile(languagePath)
+	require.NoError(t, err)
+	assert.Equal(t, "Spanish\n", string(language))
+}
+
+func TestTranslatePhase_FailureQuits(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalPath := filepath.Join(tmpDir, "transcript.original.txt")
+
+	//nolint:gosec // Test file
+	require.NoError(t, os.WriteFile(originalPath, []byte("Hola."), 0o644))
+
+	writer := &mockWriter{err: errors.New("overloaded")}
+	phase := NewTranslatePhase(context.Background(), writer, originalPath,
+		filepath.Join(tmpDir, "transcript.txt"), filepath.Join(tmpDir, "source-language.txt"))
+
+	tm := teatest.NewTestModel(t, phase, teatest.WithInitialTermSize(80, 24))
+
+	tm.WaitFinished(t, teatest.WithFinalTimeout(defaultChecker().timeout))
+}
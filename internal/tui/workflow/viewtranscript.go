@@ -9,6 +9,7 @@ import (
 	"github.com/alkime/memos/internal/tui/components/phases"
 	"github.com/alkime/memos/internal/tui/style"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,6 +17,10 @@ import (
 
 type viewTranscriptKeyMap struct {
 	Proceed key.Binding
+	Edit    key.Binding
+	Replace key.Binding
+	Confirm key.Binding
+	Cancel  key.Binding
 }
 
 func defaultViewTranscriptKeyMap() viewTranscriptKeyMap {
@@ -24,9 +29,35 @@ func defaultViewTranscriptKeyMap() viewTranscriptKeyMap {
 			key.WithKeys("y", "enter"),
 			key.WithHelp("y/enter", "generate first draft"),
 		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit in editor"),
+		),
+		Replace: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "find & replace"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "next"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
 	}
 }
 
+// viewTranscriptState tracks whether the transcript is being viewed or
+// a find/replace is being entered.
+type viewTranscriptState int
+
+const (
+	viewTranscriptViewing viewTranscriptState = iota
+	viewTranscriptFinding
+	viewTranscriptReplacing
+)
+
 // viewportReadyMsg signals that viewport content has been loaded.
 type viewportReadyMsg struct {
 	content string
@@ -34,19 +65,46 @@ type viewportReadyMsg struct {
 	height  int
 }
 
+// transcriptReplacedMsg reports the outcome of a find/replace.
+type transcriptReplacedMsg struct {
+	find, replace string
+	count         int
+}
+
 type viewTranscriptPhase struct {
 	transcriptPath string
+	launcher       EditorLauncher
 	viewport       viewport.Model
 	keys           viewTranscriptKeyMap
 	ready          bool
 	width          int
 	height         int
+
+	// Find/replace state
+	state   viewTranscriptState
+	content string
+	find    textinput.Model
+	replace textinput.Model
+	status  string
 }
 
-func NewViewTranscriptPhase(transcriptPath string) tea.Model {
+// NewViewTranscriptPhase creates a phase that shows the transcript and lets
+// the user fix it, in an external editor or with find/replace, before drafting.
+// Edits are saved back to transcriptPath.
+func NewViewTranscriptPhase(launcher EditorLauncher, transcriptPath string) tea.Model {
+	find := textinput.New()
+	find.Prompt = "Find: "
+	find.Placeholder = "misheard word"
+
+	replace := textinput.New()
+	replace.Prompt = "Replace with: "
+
 	return &viewTranscriptPhase{
 		transcriptPath: transcriptPath,
+		launcher:       launcher,
 		keys:           defaultViewTranscriptKeyMap(),
+		find:           find,
+		replace:        replace,
 	}
 }
 
@@ -54,6 +112,11 @@ func (vtp *viewTranscriptPhase) Init() tea.Cmd {
 	return tea.WindowSize()
 }
 
+// CapturingInput reports whether find/replace text is being typed.
+func (vtp *viewTranscriptPhase) CapturingInput() bool {
+	return vtp.state != viewTranscriptViewing
+}
+
 func (vtp *viewTranscriptPhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := teaMsg.(type) {
 	case tea.WindowSizeMsg:
@@ -68,10 +131,36 @@ func (vtp *viewTranscriptPhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return vtp, nil
 
+	case editorCompleteMsg:
+		if msg.err != nil {
+			slog.Error("Editor closed with error", "error", msg.err)
+		}
+		vtp.status = "Transcript reloaded from editor."
+
+		return vtp, vtp.loadViewportCmd()
+
+	case transcriptReplacedMsg:
+		vtp.status = fmt.Sprintf("Replaced %d occurrence(s) of %q with %q.", msg.count, msg.find, msg.replace)
+
+		return vtp, vtp.loadViewportCmd()
+
 	case tea.KeyMsg:
+		if vtp.state != viewTranscriptViewing {
+			return vtp.updateReplace(msg)
+		}
+
 		switch {
 		case key.Matches(msg, vtp.keys.Proceed):
 			return vtp, phases.NextPhaseCmd
+		case key.Matches(msg, vtp.keys.Edit):
+			return vtp, vtp.launcher.Launch(vtp.transcriptPath)
+		case key.Matches(msg, vtp.keys.Replace):
+			vtp.state = viewTranscriptFinding
+			vtp.status = ""
+			vtp.find.Reset()
+			vtp.replace.Reset()
+
+			return vtp, vtp.find.Focus()
 		}
 	}
 
@@ -81,6 +170,43 @@ func (vtp *viewTranscriptPhase) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 	return vtp, cmd
 }
 
+// updateReplace handles keys while find/replace text is being entered.
+func (vtp *viewTranscriptPhase) updateReplace(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, vtp.keys.Cancel):
+		vtp.state = viewTranscriptViewing
+		vtp.find.Blur()
+		vtp.replace.Blur()
+
+		return vtp, nil
+
+	case key.Matches(msg, vtp.keys.Confirm):
+		if vtp.state == viewTranscriptFinding {
+			if vtp.find.Value() == "" {
+				return vtp, nil
+			}
+			vtp.state = viewTranscriptReplacing
+			vtp.find.Blur()
+
+			return vtp, vtp.replace.Focus()
+		}
+
+		vtp.state = viewTranscriptViewing
+		vtp.replace.Blur()
+
+		return vtp, vtp.replaceCmd(vtp.find.Value(), vtp.replace.Value())
+	}
+
+	var cmd tea.Cmd
+	if vtp.state == viewTranscriptFinding {
+		vtp.find, cmd = vtp.find.Update(msg)
+	} else {
+		vtp.replace, cmd = vtp.replace.Update(msg)
+	}
+
+	return vtp, cmd
+}
+
 func (vtp *viewTranscriptPhase) View() string {
 	if !vtp.ready {
 		return "Loading transcript..."
@@ -96,38 +222,90 @@ func (vtp *viewTranscriptPhase) View() string {
 	sb.WriteString(style.Viewport.Render(vtp.viewport.View()))
 	sb.WriteString("\n\n")
 
-	// Help text
-	sb.WriteString(renderKeyHelp(vtp.keys.Proceed, "\n"))
-	sb.WriteString(renderGlobalKeyHelp())
+	switch vtp.state {
+	case viewTranscriptFinding, viewTranscriptReplacing:
+		sb.WriteString(vtp.find.View())
+		if find := vtp.find.Value(); find != "" {
+			sb.WriteString(style.Muted.Render(fmt.Sprintf("  (%d matches)", strings.Count(vtp.content, find))))
+		}
+		sb.WriteString("\n")
+		if vtp.state == viewTranscriptReplacing {
+			sb.WriteString(vtp.replace.View())
+			sb.WriteString("\n")
+		}
+		sb.WriteString(renderKeyHelp(vtp.keys.Confirm, " "))
+		sb.WriteString(renderKeyHelp(vtp.keys.Cancel, "\n"))
+
+	case viewTranscriptViewing:
+		if vtp.status != "" {
+			sb.WriteString(style.Success.Render(vtp.status))
+			sb.WriteString("\n")
+		}
+
+		// Help text
+		sb.WriteString(renderKeyHelp(vtp.keys.Proceed, " "))
+		sb.WriteString(renderKeyHelp(vtp.keys.Edit, " "))
+		sb.WriteString(renderKeyHelp(vtp.keys.Replace, "\n"))
+		sb.WriteString(renderGlobalKeyHelp())
+	}
 
 	return sb.String()
 }
 
 func (vtp *viewTranscriptPhase) loadViewportCmd() tea.Cmd {
+	// Capture the size now; the command runs outside the update loop
+	width, height := vtp.width, vtp.height
+
 	return func() tea.Msg {
 		transcript, err := vtp.readTranscriptFile()
 		if err != nil {
 			slog.Error("Failed to read transcript file", "error", err)
 
-			return tea.Quit
+			return tea.Quit()
 		}
 
 		return viewportReadyMsg{
 			content: transcript,
-			width:   vtp.width,
-			height:  vtp.height,
+			width:   width,
+			height:  height,
 		}
 	}
 }
 
+// replaceCmd replaces every occurrence of find and saves the transcript.
+func (vtp *viewTranscriptPhase) replaceCmd(find, replace string) tea.Cmd {
+	return func() tea.Msg {
+		transcript, err := vtp.readTranscriptFile()
+		if err != nil {
+			slog.Error("Failed to read transcript file", "error", err)
+
+			return tea.Quit()
+		}
+
+		count := strings.Count(transcript, find)
+		if count > 0 {
+			transcript = strings.ReplaceAll(transcript, find, replace)
+
+			//nolint:gosec // Transcript files need to be readable
+			if err := os.WriteFile(vtp.transcriptPath, []byte(transcript), 0o644); err != nil {
+				slog.Error("Failed to save transcript", "error", err, "path", vtp.transcriptPath)
+
+				return tea.Quit()
+			}
+		}
+
+		return transcriptReplacedMsg{find: find, replace: replace, count: count}
+	}
+}
+
 func (vtp *viewTranscriptPhase) setupViewport(msg viewportReadyMsg) {
 	// Account for all rendered elements:
 	// - Main model "Phase: X\n\n" = 2 lines
 	// - This view's "=== Transcript ===\n\n" = 2 lines
 	// - Viewport border = 2 lines (top + bottom)
-	// - Footer help text "\n\n" + 2 lines = 4 lines
+	// - Footer status + help text "\n\n" + 3 lines = 5 lines
 	headerHeight := 6
-	footerHeight := 4
+	footerHeight := 5
 	viewportHeight := msg.height - headerHeight - footerHeight
 	if viewportHeight < 5 {
 		viewportHeight = 5
@@ -138,6 +316,7 @@ func (vtp *viewTranscriptPhase) setupViewport(msg viewportReadyMsg) {
 		viewportWidth = 10
 	}
 
+	vtp.content = msg.content
 	vtp.viewport = viewport.New(viewportWidth, viewportHeight)
 	vtp.viewport.SetContent(wrapText(msg.content, viewportWidth))
 }
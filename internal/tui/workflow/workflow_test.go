@@ -2,7 +2,10 @@ package workflow
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/exp/teatest"
 	"github.com/muesli/termenv"
+	"github.com/openai/openai-go"
 )
 
 func init() {
@@ -48,39 +52,137 @@ type mockTranscriber struct {
 	result string
 	err    error
 	called bool
+
+	// retry, when set, fails the first attempt with a rate limit under this policy
+	retry *content.RetryPolicy
 }
 
-func (m *mockTranscriber) TranscribeFile(_ io.Reader) (string, error) {
+func (m *mockTranscriber) TranscribeFile(ctx context.Context, _ io.Reader) (string, error) {
 	m.called = true
+	if m.retry != nil {
+		attempts := 0
+		err := m.retry.Do(ctx, func(context.Context) error {
+			attempts++
+			if attempts == 1 {
+				return &openai.Error{StatusCode: http.StatusTooManyRequests}
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
 	return m.result, m.err
 }
 
 // mockWriter implements Writer for testing.
 type mockWriter struct {
-	firstDraftResult string
-	copyEditResult   *content.CopyEditResult
-	err              error
-	firstDraftCalled bool
-	copyEditCalled   bool
+	firstDraftResult  string
+	copyEditResult    *content.CopyEditResult
+	translationResult *content.TranslationResult
+	revisionResult    *content.CopyEditResult
+	revisionErr       error
+	socialResult      []content.SocialPost
+	err               error
+	firstDraftCalled  bool
+	copyEditCalled    bool
+	translateCalled   bool
+
+	// partialDraft is streamed before a blocking first draft request waits
+	partialDraft string
+
+	// block makes requests wait for context cancellation, recording its error
+	block     bool
+	cancelled chan error
+}
+
+func (m *mockWriter) wait(ctx context.Context) error {
+	if !m.block {
+		return nil
+	}
+
+	<-ctx.Done()
+	if m.cancelled != nil {
+		m.cancelled <- ctx.Err()
+	}
+
+	return ctx.Err()
 }
 
-func (m *mockWriter) GenerateFirstDraft(_ string, _ content.Mode) (string, error) {
+func (m *mockWriter) GenerateFirstDraft(
+	ctx context.Context,
+	_ string,
+	_ content.Mode,
+	onText func(string),
+) (string, error) {
 	m.firstDraftCalled = true
+	if onText != nil && m.partialDraft != "" {
+		onText(m.partialDraft)
+	}
+	if err := m.wait(ctx); err != nil {
+		return "", err
+	}
 	return m.firstDraftResult, m.err
 }
 
-func (m *mockWriter) GenerateCopyEdit(_, _ string, _ content.Mode) (*content.CopyEditResult, error) {
+func (m *mockWriter) GenerateCopyEdit(
+	ctx context.Context,
+	_, _ string,
+	_ content.Mode,
+	_ *content.TagIndex,
+) (*content.CopyEditResult, error) {
 	m.copyEditCalled = true
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
 	return m.copyEditResult, m.err
 }
 
+func (m *mockWriter) ReviseCopyEdit(
+	ctx context.Context,
+	_, _ string,
+	_ content.Mode,
+	_ *content.TagIndex,
+) (*content.CopyEditResult, error) {
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	if m.revisionErr != nil {
+		return nil, m.revisionErr
+	}
+	return m.revisionResult, m.err
+}
+
+func (m *mockWriter) TranslateTranscript(ctx context.Context, _ string) (*content.TranslationResult, error) {
+	m.translateCalled = true
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	return m.translationResult, m.err
+}
+
+func (m *mockWriter) GenerateSocialPosts(
+	ctx context.Context,
+	_ string,
+	_ []content.SocialPlatform,
+) ([]content.SocialPost, error) {
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	return m.socialResult, m.err
+}
+
 // mockEditorLauncher implements EditorLauncher for testing.
+// Launch runs in the program's goroutine, so its fields are guarded by mu.
 type mockEditorLauncher struct {
+	mu       sync.Mutex
 	launched bool
 	filePath string
 }
 
 func (m *mockEditorLauncher) Launch(filePath string) tea.Cmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.launched = true
 	m.filePath = filePath
 	return func() tea.Msg {
@@ -88,6 +190,13 @@ func (m *mockEditorLauncher) Launch(filePath string) tea.Cmd {
 	}
 }
 
+// launchedWith returns the file the editor was launched with, if any.
+func (m *mockEditorLauncher) launchedWith() (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.filePath, m.launched
+}
+
 // mockKnob implements remotectl.Knob for testing.
 type mockKnob struct {
 	state bool